@@ -2,10 +2,14 @@ package app
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/JLevconoks/k8ConsoleViewer/clipboard"
+	"github.com/JLevconoks/k8ConsoleViewer/terminal"
 	"github.com/gdamore/tcell/v2"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"log"
 	"os"
 	"regexp"
@@ -13,35 +17,125 @@ import (
 	"time"
 )
 
+// Group is a definition from groups.json, Grouping is optional and overrides pod grouping rules from config.yaml.
 type Group struct {
-	Id       int       `json:"id"`
-	Name     string    `json:"name"`
-	NsGroups []NsGroup `json:"nsGroups"`
+	Id       int            `json:"id"`
+	Name     string         `json:"name"`
+	NsGroups []NsGroup      `json:"nsGroups"`
+	Grouping []GroupingRule `json:"grouping,omitempty"`
 }
 
+// NsGroup is a context with its namespaces, optional selectors are used when listing and watching pods.
 type NsGroup struct {
-	Context    string   `json:"context"`
-	Namespaces []string `json:"namespaces"`
+	Context       string   `json:"context"`
+	Namespaces    []string `json:"namespaces"`
+	LabelSelector string   `json:"labelSelector,omitempty"`
+	FieldSelector string   `json:"fieldSelector,omitempty"`
+}
+
+// withSelector returns a copy of the group with label selector combined with selectors of every NsGroup.
+func (g Group) withSelector(labelSelector string) Group {
+	if labelSelector == "" {
+		return g
+	}
+	nsGroups := make([]NsGroup, len(g.NsGroups))
+	for index := range g.NsGroups {
+		nsGroups[index] = g.NsGroups[index]
+		nsGroups[index].LabelSelector = combineSelectors(g.NsGroups[index].LabelSelector, labelSelector)
+	}
+	g.NsGroups = nsGroups
+	return g
+}
+
+// validateSelectors checks every NsGroup selector, so typos are reported before the screen is started.
+func (g Group) validateSelectors() error {
+	for index := range g.NsGroups {
+		if _, err := labels.Parse(g.NsGroups[index].LabelSelector); err != nil {
+			return errors.New(fmt.Sprintf("invalid label selector '%v' for context %v: %v", g.NsGroups[index].LabelSelector, g.NsGroups[index].Context, err))
+		}
+		if _, err := fields.ParseSelector(g.NsGroups[index].FieldSelector); err != nil {
+			return errors.New(fmt.Sprintf("invalid field selector '%v' for context %v: %v", g.NsGroups[index].FieldSelector, g.NsGroups[index].Context, err))
+		}
+	}
+	return nil
+}
+
+// selectorInfo returns distinct selectors used by NsGroups to be displayed in the header, empty when none are set.
+func (g Group) selectorInfo() string {
+	seen := make(map[string]struct{})
+	infos := make([]string, 0)
+	for index := range g.NsGroups {
+		info := make([]string, 0, 2)
+		if g.NsGroups[index].LabelSelector != "" {
+			info = append(info, "-l "+g.NsGroups[index].LabelSelector)
+		}
+		if g.NsGroups[index].FieldSelector != "" {
+			info = append(info, "--field-selector "+g.NsGroups[index].FieldSelector)
+		}
+		value := strings.Join(info, " ")
+		if _, ok := seen[value]; ok || value == "" {
+			continue
+		}
+		seen[value] = struct{}{}
+		infos = append(infos, value)
+	}
+	return strings.Join(infos, ", ")
+}
+
+func combineSelectors(selectors ...string) string {
+	nonEmpty := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		if selector != "" {
+			nonEmpty = append(nonEmpty, selector)
+		}
+	}
+	return strings.Join(nonEmpty, ",")
+}
+
+func (g Group) namespaceCount() int {
+	count := 0
+	for index := range g.NsGroups {
+		count += len(g.NsGroups[index].Namespaces)
+	}
+	return count
 }
 
 type App struct {
-	k8Client Client
-	group    Group
+	k8Client        Client
+	group           Group
+	refreshInterval time.Duration
+	showEvents      bool
+	showMetrics     bool
+	recordPath      string
+	grouping        []groupingRule
+	// columns are pod columns shown after NAME column.
+	columns []podColumn
+	// theme maps item states to styles, it becomes activeTheme when the app is run.
+	theme *Theme
+	// mouse enables mouse events, when it is off terminal handles mouse itself, e.g. for text selection.
+	mouse bool
+	// logViewer is either LogViewerApp for in-app log pane or LogViewerTerminal for terminal windows.
+	logViewer string
+	terminal  terminal.Backend
+	clipboard *clipboard.Clipboard
+	// replay is set when app is driven from a record file instead of the cluster.
+	replay *replayer
 	// This is a bit ugly, but will do for now...
 	commandShortcuts map[Type]map[rune]ClipboardShortcut
 }
 
 func NewApp(context string, namespace string, settings map[string]interface{}) (App, error) {
+	kubeconfig := getKubeconfig(settings)
 	if context == "" {
 		var err error
-		context, err = CurrentContextName()
+		context, err = CurrentContextName(kubeconfig)
 		if err != nil {
 			return App{}, err
 		}
 	}
 	contextNameSet := make(map[string]struct{})
 	contextNameSet[context] = struct{}{}
-	k8Client, err := NewK8ClientSets(contextNameSet)
+	k8Client, err := NewK8ClientSets(kubeconfig, contextNameSet)
 	if err != nil {
 		return App{}, err
 	}
@@ -55,24 +149,71 @@ func NewApp(context string, namespace string, settings map[string]interface{}) (
 	} else {
 		g = buildGroup(fmt.Sprintf("%v/%v", context, namespace), context, namespace)
 	}
+	g = g.withSelector(getSelector(settings))
+	if err := g.validateSelectors(); err != nil {
+		return App{}, err
+	}
 
 	cs, err := getClipboardShortcuts(settings)
 	if err != nil {
 		return App{}, err
 	}
+	interval, err := getRefreshInterval(settings)
+	if err != nil {
+		return App{}, err
+	}
+	grouping, err := getGroupingRules(g, settings)
+	if err != nil {
+		return App{}, err
+	}
+	logViewer, err := getLogViewer(settings)
+	if err != nil {
+		return App{}, err
+	}
+	term, err := getTerminal(settings)
+	if err != nil {
+		return App{}, err
+	}
+	cb, err := getClipboard(settings)
+	if err != nil {
+		return App{}, err
+	}
+	theme, err := getTheme(settings)
+	if err != nil {
+		return App{}, err
+	}
+	columns, err := getPodColumns(settings, getShowMetrics(settings))
+	if err != nil {
+		return App{}, err
+	}
 	return App{
 		k8Client:         k8Client,
 		group:            g,
+		grouping:         grouping,
+		refreshInterval:  interval,
+		showEvents:       getShowEvents(settings),
+		showMetrics:      getShowMetrics(settings),
+		recordPath:       getRecordPath(settings),
+		logViewer:        logViewer,
+		terminal:         term,
+		clipboard:        cb,
+		mouse:            getMouse(settings),
+		theme:            theme,
+		columns:          columns,
 		commandShortcuts: cs,
 	}, nil
 }
 
 func NewAppFromGroup(group Group, settings map[string]interface{}) (App, error) {
+	group = group.withSelector(getSelector(settings))
+	if err := group.validateSelectors(); err != nil {
+		return App{}, err
+	}
 	contextNameSet := make(map[string]struct{})
 	for i := range group.NsGroups {
 		contextNameSet[group.NsGroups[i].Context] = struct{}{}
 	}
-	k8Client, err := NewK8ClientSets(contextNameSet)
+	k8Client, err := NewK8ClientSets(getKubeconfig(settings), contextNameSet)
 	if err != nil {
 		return App{}, err
 	}
@@ -81,10 +222,94 @@ func NewAppFromGroup(group Group, settings map[string]interface{}) (App, error)
 	if err != nil {
 		return App{}, err
 	}
+	interval, err := getRefreshInterval(settings)
+	if err != nil {
+		return App{}, err
+	}
+	grouping, err := getGroupingRules(group, settings)
+	if err != nil {
+		return App{}, err
+	}
+	logViewer, err := getLogViewer(settings)
+	if err != nil {
+		return App{}, err
+	}
+	term, err := getTerminal(settings)
+	if err != nil {
+		return App{}, err
+	}
+	cb, err := getClipboard(settings)
+	if err != nil {
+		return App{}, err
+	}
+	theme, err := getTheme(settings)
+	if err != nil {
+		return App{}, err
+	}
+	columns, err := getPodColumns(settings, getShowMetrics(settings))
+	if err != nil {
+		return App{}, err
+	}
 
 	return App{
 		k8Client:         k8Client,
 		group:            group,
+		grouping:         grouping,
+		refreshInterval:  interval,
+		showEvents:       getShowEvents(settings),
+		showMetrics:      getShowMetrics(settings),
+		recordPath:       getRecordPath(settings),
+		logViewer:        logViewer,
+		terminal:         term,
+		clipboard:        cb,
+		mouse:            getMouse(settings),
+		theme:            theme,
+		columns:          columns,
+		commandShortcuts: cs,
+	}, nil
+}
+
+// NewReplayApp creates an app that replays frames from a file written with --record, instead of connecting to cluster.
+func NewReplayApp(path string, settings map[string]interface{}) (App, error) {
+	frames, err := readRecordFrames(path)
+	if err != nil {
+		return App{}, err
+	}
+
+	cs, err := getClipboardShortcuts(settings)
+	if err != nil {
+		return App{}, err
+	}
+	grouping, err := getGroupingRules(Group{}, settings)
+	if err != nil {
+		return App{}, err
+	}
+	term, err := getTerminal(settings)
+	if err != nil {
+		return App{}, err
+	}
+	cb, err := getClipboard(settings)
+	if err != nil {
+		return App{}, err
+	}
+	theme, err := getTheme(settings)
+	if err != nil {
+		return App{}, err
+	}
+	columns, err := getPodColumns(settings, false)
+	if err != nil {
+		return App{}, err
+	}
+
+	return App{
+		group:            Group{Name: frames[0].Group},
+		grouping:         grouping,
+		replay:           newReplayer(frames),
+		terminal:         term,
+		clipboard:        cb,
+		mouse:            getMouse(settings),
+		theme:            theme,
+		columns:          columns,
 		commandShortcuts: cs,
 	}, nil
 }
@@ -105,67 +330,205 @@ func getClipboardShortcuts(settings map[string]interface{}) (map[Type]map[rune]C
 	return cs, nil
 }
 
+// getKubeconfig reads 'kubeconfig' setting, empty value means default loading rules will be used.
+func getKubeconfig(settings map[string]interface{}) string {
+	kubeconfig, _ := settings["kubeconfig"].(string)
+	return kubeconfig
+}
+
+// getShowEvents reads 'events' setting, warning events are fetched only when it is enabled.
+func getShowEvents(settings map[string]interface{}) bool {
+	showEvents, _ := settings["events"].(bool)
+	return showEvents
+}
+
+// getShowMetrics reads 'metrics' setting, CPU and MEMORY columns are shown only when it is enabled.
+func getShowMetrics(settings map[string]interface{}) bool {
+	showMetrics, _ := settings["metrics"].(bool)
+	return showMetrics
+}
+
+// getSelector reads 'selector' setting, a label selector applied to all namespaces in addition to groups.json ones.
+func getSelector(settings map[string]interface{}) string {
+	selector, _ := settings["selector"].(string)
+	return selector
+}
+
+// getRecordPath reads 'record' setting, empty value means refreshes are not recorded.
+func getRecordPath(settings map[string]interface{}) string {
+	path, _ := settings["record"].(string)
+	return path
+}
+
+// getLogViewer reads 'logViewer' setting, logs are shown in the app by default.
+func getLogViewer(settings map[string]interface{}) (string, error) {
+	value, ok := settings["logviewer"]
+	if !ok {
+		return LogViewerApp, nil
+	}
+	logViewer, _ := value.(string)
+	if logViewer != LogViewerApp && logViewer != LogViewerTerminal {
+		return "", errors.New(fmt.Sprintf("invalid logViewer '%v', should be '%v' or '%v'", value, LogViewerApp, LogViewerTerminal))
+	}
+	return logViewer, nil
+}
+
+// getMouse reads 'mouse' setting, mouse is enabled by default.
+func getMouse(settings map[string]interface{}) bool {
+	mouse, ok := settings["mouse"].(bool)
+	return !ok || mouse
+}
+
+// getClipboard reads 'clipboard' setting, backends are detected automatically by default.
+func getClipboard(settings map[string]interface{}) (*clipboard.Clipboard, error) {
+	name, ok := settings["clipboard"].(string)
+	if !ok {
+		name = clipboard.Auto
+	}
+	return clipboard.New(name)
+}
+
+// getTerminal reads 'terminal' setting, iTerm2 is used by default.
+func getTerminal(settings map[string]interface{}) (terminal.Backend, error) {
+	name, ok := settings["terminal"].(string)
+	if !ok {
+		name = terminal.BackendITerm
+	}
+	return terminal.NewBackend(name)
+}
+
+// getRefreshInterval reads 'interval' setting, it can come from config file or --interval flag as a duration string,
+// plain numbers are treated as seconds.
+func getRefreshInterval(settings map[string]interface{}) (time.Duration, error) {
+	value, ok := settings["interval"]
+	if !ok {
+		return DefaultRefreshInterval, nil
+	}
+
+	var interval time.Duration
+	switch v := value.(type) {
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, errors.New(fmt.Sprintf("invalid interval '%v': %v", v, err))
+		}
+		interval = d
+	case int:
+		interval = time.Duration(v) * time.Second
+	case float64:
+		interval = time.Duration(v * float64(time.Second))
+	default:
+		return 0, errors.New(fmt.Sprintf("invalid interval '%v'", v))
+	}
+
+	if interval <= 0 {
+		return 0, errors.New(fmt.Sprintf("interval must be positive, got '%v'", value))
+	}
+	return interval, nil
+}
+
 func (app *App) Run() {
-	s, e := tcell.NewScreen()
+	screen, e := tcell.NewScreen()
 
 	if e != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", e)
 		os.Exit(1)
 	}
-	if e = s.Init(); e != nil {
+	if e = screen.Init(); e != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", e)
 		os.Exit(1)
 	}
+	// tcell draws to the controlling terminal, OSC 52 sequences are written there as well.
+	s := &syncScreen{Screen: screen, out: os.Stdout}
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		defer tty.Close()
+		s.out = tty
+	}
+	app.clipboard.SetTerminal(s.writeRaw)
 
+	activeTheme = app.theme
+	if app.mouse {
+		s.EnableMouse()
+	}
 	s.Clear()
-	gui := NewGui(s, app.group.Name)
+	gui := NewGui(s, app.group.Name, app.group.selectorInfo())
+	gui.mainFrame.showMetrics = app.showMetrics
+	gui.mainFrame.grouping = app.grouping
+	gui.mainFrame.columns = app.columns
+	gui.terminal = app.terminal
+	gui.describeFrame.clipboard = app.clipboard
+	if app.replay == nil {
+		gui.logStreamer = app.k8Client
+		gui.describer = app.k8Client
+	}
 	gui.footerFrame.clipboardShortcuts = getShortcutDisplayMap(app.commandShortcuts)
 	gui.show(s)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	quit := make(chan []string)
-	// Get namespace info loop.
-	go func() {
-		for {
-			gui.statusBarCh <- "Updating namespace info..."
-			startTime := time.Now()
-			podListResults := app.k8Client.podLists(app.group)
-			endTime := time.Now()
-
-			errorMessages := make([]string, 0)
-			for index := range podListResults {
-				if podListResults[index].error != nil {
-					errorMessages = append(errorMessages, fmt.Sprintf("Context: %v Namespace: %v, Error: %v", podListResults[index].context, podListResults[index].namespace, podListResults[index].Error()))
-				}
-			}
-			if len(errorMessages) == len(podListResults) {
-				quit <- errorMessages
-				close(quit)
+	if app.replay != nil {
+		go app.replay.run(ctx, s, &gui)
+	} else {
+		var rec *recorder
+		if app.recordPath != "" {
+			var err error
+			rec, err = newRecorder(app.recordPath, app.group.Name)
+			if err != nil {
+				s.Fini()
+				log.Fatal(err)
 			}
-
-			gui.updateNamespaces(s, podListResults, endTime.Sub(startTime))
-
-			time.Sleep(5 * time.Second)
+			defer rec.close()
 		}
-	}()
+		go app.watchNamespaces(ctx, s, &gui, rec, quit)
+	}
 
 	go func() {
 		previousKeyEvent := tcell.EventKey{}
+		clicks := clickTracker{}
 		for {
 			ev := s.PollEvent()
 			switch ev := ev.(type) {
+			case *tcell.EventMouse:
+				gui.handleMouse(ev, clicks.pressed(ev))
 			case *tcell.EventKey:
-				// This is to ignore event spam primarily from mouse scroll
-				if previousKeyEvent.Key() == ev.Key() && ev.When().Sub(previousKeyEvent.When()) < 5*time.Millisecond {
+				// This is to ignore event spam from mouse scroll, which terminals send as arrow keys when mouse is off.
+				if !app.mouse && previousKeyEvent.Key() == ev.Key() && ev.When().Sub(previousKeyEvent.When()) < 5*time.Millisecond {
 					break
 				}
 				previousKeyEvent = *ev
 
+				if app.replay != nil && app.replay.handleKey(ev) {
+					continue
+				}
+				if gui.overlay() != nil {
+					gui.handleOverlayKey(ev)
+					continue
+				}
+				if gui.searchInput {
+					gui.handleSearchKey(ev)
+					continue
+				}
+				// n/N navigate between matches while filter is active, otherwise they can be used as clipboard shortcuts.
+				if gui.mainFrame.filter != nil && ev.Key() == tcell.KeyRune && (ev.Rune() == 'n' || ev.Rune() == 'N') {
+					gui.jumpToMatch(ev.Rune() == 'n')
+					continue
+				}
+
 				switch ev.Key() {
 				case tcell.KeyEscape:
 					if gui.popupFrame.visible {
 						gui.hidePopupFrame()
 						continue
 					}
+					if gui.mainFrame.filter != nil {
+						gui.clearSearch()
+						continue
+					}
+					if gui.clearMarks() {
+						continue
+					}
 					fallthrough
 				case tcell.KeyCtrlC:
 					close(quit)
@@ -175,8 +538,16 @@ func (app *App) Run() {
 				case tcell.KeyUp:
 					gui.handleKeyUp()
 				case tcell.KeyLeft:
+					if ev.Modifiers()&tcell.ModShift != 0 {
+						gui.scrollColumns(-HorizontalScrollStep)
+						continue
+					}
 					gui.handleKeyLeft()
 				case tcell.KeyRight:
+					if ev.Modifiers()&tcell.ModShift != 0 {
+						gui.scrollColumns(HorizontalScrollStep)
+						continue
+					}
 					gui.handleKeyRight()
 				case tcell.KeyPgUp:
 					gui.handlePageUp()
@@ -189,9 +560,27 @@ func (app *App) Run() {
 				case tcell.KeyCtrlE:
 					gui.execToPods()
 				case tcell.KeyCtrlL:
-					gui.getLogsFromPods()
+					if app.logViewer == LogViewerTerminal {
+						gui.getLogsFromPods()
+					} else {
+						gui.showLogs(false)
+					}
 				case tcell.KeyCtrlK:
-					gui.getLogsAndFollowFromPods()
+					if app.logViewer == LogViewerTerminal {
+						gui.getLogsAndFollowFromPods()
+					} else {
+						gui.showLogs(true)
+					}
+				case tcell.KeyCtrlD:
+					gui.toggleDetailsFrame()
+				case tcell.KeyCtrlU:
+					gui.toggleUnhealthyOnly()
+				case tcell.KeyCtrlS:
+					gui.cycleSortMode()
+				case tcell.KeyCtrlY:
+					gui.showDescribe()
+				case tcell.KeyCtrlA:
+					gui.toggleMarkAll()
 				case tcell.KeyEnter:
 					gui.handleEnterKey()
 				}
@@ -200,8 +589,24 @@ func (app *App) Run() {
 					gui.handleCollapseEvent()
 				case 'e':
 					gui.handleExpandEvent()
+				case '/':
+					gui.startSearch()
+				case ' ':
+					gui.toggleMark()
 				default:
-					data := gui.getCurrentGuiItemInfo()
+					if marked := gui.markedItemInfos(); len(marked) > 0 {
+						count, err := app.handleMarkedClipboardShortcut(ev.Rune(), marked)
+						if err != nil {
+							gui.statusBarCh <- "Error: " + err.Error()
+						} else if count > 0 {
+							gui.statusBarCh <- fmt.Sprintf("Clipboard: %d lines for marked pods", count)
+						}
+						continue
+					}
+					data, ok := gui.getCurrentGuiItemInfo()
+					if !ok {
+						continue
+					}
 					value, err := app.handleClipboardShortcut(ev.Rune(), data)
 					if err != nil {
 						gui.statusBarCh <- "Error: " + err.Error()
@@ -231,7 +636,166 @@ func (app *App) Run() {
 	}
 }
 
+// watchNamespaces applies watch updates as they come and redraws cached results periodically to keep age and time
+// columns up to date. Controllers status and warning events, when enabled, are fetched on the same interval. Every update
+// is written to rec, when it is provided.
+func (app *App) watchNamespaces(ctx context.Context, s tcell.Screen, gui *Gui, rec *recorder, quit chan<- []string) {
+	updateCh := make(chan PodListResult)
+	app.k8Client.watchPods(ctx, app.group, app.refreshInterval, updateCh)
+
+	detailsCh := make(chan map[string]namespaceDetails)
+	details := make(map[string]namespaceDetails)
+	fetchingDetails := false
+	fetchDetails := func(latest map[string]PodListResult) {
+		if fetchingDetails {
+			return
+		}
+		fetchingDetails = true
+		kinds := make(map[string]map[string]struct{}, len(latest))
+		for name, plr := range latest {
+			kinds[name] = controllerKinds(plr.Items)
+		}
+		go func() {
+			result := app.k8Client.groupDetails(ctx, app.group, app.showEvents, app.showMetrics, kinds)
+			select {
+			case detailsCh <- result:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	gui.statusBarCh <- "Updating namespace info..."
+	latest := make(map[string]PodListResult)
+	// listed are namespaces which were listed successfully at least once.
+	listed := make(map[string]struct{})
+	ticker := time.NewTicker(app.refreshInterval)
+	defer ticker.Stop()
+	for {
+		var podListResults []PodListResult
+		changed := false
+		select {
+		case plr := <-updateCh:
+			changed = true
+			podListResults = append(podListResults, plr)
+			latest[plr.DisplayName()] = plr
+			if len(details) == 0 {
+				fetchDetails(latest)
+			}
+			// Drain everything that is already waiting to avoid redrawing for every single event.
+			for drained := false; !drained; {
+				select {
+				case plr := <-updateCh:
+					podListResults = append(podListResults, plr)
+				default:
+					drained = true
+				}
+			}
+		case result := <-detailsCh:
+			changed = true
+			fetchingDetails = false
+			details = result
+			for _, plr := range latest {
+				podListResults = append(podListResults, plr)
+			}
+		case <-ticker.C:
+			fetchDetails(latest)
+			for _, plr := range latest {
+				podListResults = append(podListResults, plr)
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		var timeToExec time.Duration
+		for index := range podListResults {
+			nsDetails := details[podListResults[index].DisplayName()]
+			podListResults[index].events = nsDetails.events
+			podListResults[index].eventsError = nsDetails.eventsError
+			podListResults[index].workloads = nsDetails.workloads
+			podListResults[index].workloadsError = nsDetails.workloadsError
+			podListResults[index].metrics = nsDetails.metrics
+			podListResults[index].metricsError = nsDetails.metricsError
+			latest[podListResults[index].DisplayName()] = podListResults[index]
+			if podListResults[index].error == nil {
+				listed[podListResults[index].DisplayName()] = struct{}{}
+			}
+			if podListResults[index].duration > timeToExec {
+				timeToExec = podListResults[index].duration
+			}
+		}
+
+		if rec != nil && changed {
+			if err := rec.record(podListResults); err != nil {
+				gui.statusBarCh <- "Error recording: " + err.Error()
+			}
+		}
+
+		if len(latest) == app.group.namespaceCount() {
+			if errorMessages := startupErrors(latest, listed); len(errorMessages) > 0 {
+				quit <- errorMessages
+				close(quit)
+				return
+			}
+		}
+
+		gui.updateNamespaces(s, podListResults, timeToExec)
+	}
+}
+
+// startupErrors returns error messages when every namespace failed without being listed even once. Namespaces failing
+// after they were listed are being retried, so they are displayed in their error state instead.
+func startupErrors(latest map[string]PodListResult, listed map[string]struct{}) []string {
+	if len(listed) > 0 {
+		return nil
+	}
+	errorMessages := make([]string, 0, len(latest))
+	for _, plr := range latest {
+		if plr.error == nil {
+			return nil
+		}
+		errorMessages = append(errorMessages, fmt.Sprintf("Context: %v Namespace: %v, Error: %v", plr.context, plr.namespace, plr.Error()))
+	}
+	return errorMessages
+}
+
 func (app *App) handleClipboardShortcut(r rune, data GuiItemInfo) (string, error) {
+	value, err := app.renderClipboardShortcut(r, data)
+	if err != nil || value == "" {
+		return "", err
+	}
+
+	err = app.clipboard.Copy(value)
+	if err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// handleMarkedClipboardShortcut copies shortcut rendered for every marked pod, one per line, and returns number of lines.
+func (app *App) handleMarkedClipboardShortcut(r rune, items []GuiItemInfo) (int, error) {
+	lines := make([]string, 0, len(items))
+	for _, data := range items {
+		value, err := app.renderClipboardShortcut(r, data)
+		if err != nil {
+			return 0, err
+		}
+		if value != "" {
+			lines = append(lines, value)
+		}
+	}
+	if len(lines) == 0 {
+		return 0, nil
+	}
+
+	if err := app.clipboard.Copy(strings.Join(lines, "\n")); err != nil {
+		return 0, err
+	}
+	return len(lines), nil
+}
+
+// renderClipboardShortcut returns shortcut value for the item, empty string when there is no shortcut for the key.
+func (app *App) renderClipboardShortcut(r rune, data GuiItemInfo) (string, error) {
 	scMap, ok := app.commandShortcuts[data.itemType]
 	if !ok {
 		return "", nil
@@ -247,18 +811,7 @@ func (app *App) handleClipboardShortcut(r rune, data GuiItemInfo) (string, error
 	if err != nil {
 		return "", err
 	}
-
-	value := buf.String()
-	if value == "" {
-		return "", nil
-	}
-
-	err = clipboard.ToClipboard(value)
-	if err != nil {
-		return "", err
-	}
-
-	return value, nil
+	return buf.String(), nil
 }
 
 func buildGroup(groupName string, context string, namespace ...string) Group {
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGroupWithSelector(t *testing.T) {
+	group := Group{
+		Name: "foo",
+		NsGroups: []NsGroup{
+			{Context: "dev", Namespaces: []string{"ns1"}},
+			{Context: "stage", Namespaces: []string{"ns1"}, LabelSelector: "tier=frontend", FieldSelector: "status.phase=Running"},
+		},
+	}
+
+	selected := group.withSelector("app=web")
+	if selected.NsGroups[0].LabelSelector != "app=web" {
+		t.Errorf("Invalid label selector. Want: %v, Got: %v", "app=web", selected.NsGroups[0].LabelSelector)
+	}
+	if selected.NsGroups[1].LabelSelector != "tier=frontend,app=web" {
+		t.Errorf("Invalid combined label selector. Want: %v, Got: %v", "tier=frontend,app=web", selected.NsGroups[1].LabelSelector)
+	}
+	if group.NsGroups[0].LabelSelector != "" {
+		t.Errorf("Original group should not be modified, got: %v", group.NsGroups[0].LabelSelector)
+	}
+
+	expectedInfo := "-l app=web, -l tier=frontend,app=web --field-selector status.phase=Running"
+	if info := selected.selectorInfo(); info != expectedInfo {
+		t.Errorf("Invalid selector info. Want: %v, Got: %v", expectedInfo, info)
+	}
+	if info := (Group{NsGroups: []NsGroup{{Context: "dev"}}}).selectorInfo(); info != "" {
+		t.Errorf("Selector info should be empty without selectors, got: %v", info)
+	}
+}
+
+func TestGroupValidateSelectors(t *testing.T) {
+	testTable := []struct {
+		name    string
+		nsGroup NsGroup
+		wantErr bool
+	}{
+		{name: "empty", nsGroup: NsGroup{Context: "dev"}, wantErr: false},
+		{name: "valid", nsGroup: NsGroup{Context: "dev", LabelSelector: "app in (web, api),!canary", FieldSelector: "spec.nodeName=node-1"}, wantErr: false},
+		{name: "invalid_label", nsGroup: NsGroup{Context: "dev", LabelSelector: "app in web"}, wantErr: true},
+		{name: "invalid_field", nsGroup: NsGroup{Context: "dev", FieldSelector: "spec.nodeName"}, wantErr: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Group{NsGroups: []NsGroup{tc.nsGroup}}.validateSelectors()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Unexpected validation result. Want error: %v, Got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestStartupErrors(t *testing.T) {
+	failed := PodListResult{context: "dev", namespace: "ns1", error: errors.New("connection refused")}
+	ok := PodListResult{context: "dev", namespace: "ns2"}
+	testTable := []struct {
+		name     string
+		latest   []PodListResult
+		listed   []string
+		expected int
+	}{
+		{name: "all_failed_before_list", latest: []PodListResult{failed}, expected: 1},
+		{name: "one_listed", latest: []PodListResult{failed, ok}, listed: []string{ok.DisplayName()}},
+		{name: "all_failed_after_list", latest: []PodListResult{failed}, listed: []string{failed.DisplayName()}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			latest := make(map[string]PodListResult)
+			for _, plr := range tc.latest {
+				latest[plr.DisplayName()] = plr
+			}
+			listed := make(map[string]struct{})
+			for _, name := range tc.listed {
+				listed[name] = struct{}{}
+			}
+			if got := startupErrors(latest, listed); len(got) != tc.expected {
+				t.Errorf("Invalid startup errors. Want: %v, Got: %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package app
+
+import "time"
+
+const maxRetryDelay = 5 * time.Minute
+
+// backoff calculates retry delays for a single context/namespace pair, delay is doubled after every failure starting
+// from refresh interval and capped at maxRetryDelay.
+type backoff struct {
+	interval time.Duration
+	failures int
+}
+
+func (b *backoff) next() time.Duration {
+	delay := b.interval
+	for i := 0; i < b.failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	b.failures++
+	return delay
+}
+
+func (b *backoff) reset() {
+	b.failures = 0
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffNext(t *testing.T) {
+	b := backoff{interval: 5 * time.Second}
+	expected := []time.Duration{
+		5 * time.Second,
+		10 * time.Second,
+		20 * time.Second,
+		40 * time.Second,
+		80 * time.Second,
+		160 * time.Second,
+		maxRetryDelay,
+		maxRetryDelay,
+	}
+
+	for index, want := range expected {
+		if got := b.next(); got != want {
+			t.Errorf("Invalid delay for failure %v. Want: %v, Got: %v", index, want, got)
+		}
+	}
+
+	b.reset()
+	if got := b.next(); got != 5*time.Second {
+		t.Errorf("Invalid delay after reset. Want: %v, Got: %v", 5*time.Second, got)
+	}
+}
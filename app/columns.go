@@ -0,0 +1,310 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"strconv"
+	"strings"
+)
+
+const (
+	ColumnReady       = "ready"
+	ColumnStatus      = "status"
+	ColumnRestarts    = "restarts"
+	ColumnAge         = "age"
+	ColumnNode        = "node"
+	ColumnIP          = "ip"
+	ColumnImage       = "image"
+	ColumnQoS         = "qos"
+	ColumnLastRestart = "lastrestart"
+	ColumnOwner       = "owner"
+	ColumnCPU         = "cpu"
+	ColumnMemory      = "memory"
+
+	// HorizontalScrollStep is number of characters InfoFrame is scrolled by with Shift+Left/Right.
+	HorizontalScrollStep = 8
+)
+
+// podColumn is a pod value displayed after the NAME column.
+type podColumn struct {
+	name  string
+	title string
+	value func(p *Pod) string
+}
+
+var podColumns = []podColumn{
+	{name: ColumnReady, title: "READY", value: func(p *Pod) string { return p.ReadyString() }},
+	{name: ColumnStatus, title: "STATUS", value: func(p *Pod) string { return p.status }},
+	{name: ColumnRestarts, title: "RESTARTS", value: func(p *Pod) string { return strconv.Itoa(p.restarts) }},
+	{name: ColumnAge, title: "AGE", value: func(p *Pod) string { return p.age }},
+	{name: ColumnNode, title: "NODE", value: func(p *Pod) string { return p.details.nodeName }},
+	{name: ColumnIP, title: "IP", value: func(p *Pod) string { return p.details.podIP }},
+	{name: ColumnImage, title: "IMAGE", value: podImages},
+	{name: ColumnQoS, title: "QOS", value: func(p *Pod) string { return p.details.qosClass }},
+	{name: ColumnLastRestart, title: "LAST RESTART", value: podLastRestart},
+	{name: ColumnOwner, title: "OWNER", value: podOwnerName},
+	{name: ColumnCPU, title: "CPU", value: func(p *Pod) string { cpu, _ := p.metricsCells(); return cpu }},
+	{name: ColumnMemory, title: "MEMORY", value: func(p *Pod) string { _, memory := p.metricsCells(); return memory }},
+}
+
+// defaultColumns are used when 'columns' setting is not present.
+var defaultColumns = []string{ColumnReady, ColumnStatus, ColumnRestarts, ColumnAge}
+
+// podImages returns distinct images of regular containers.
+func podImages(p *Pod) string {
+	images := make([]string, 0)
+	seen := make(map[string]struct{})
+	for index := range p.containers {
+		c := &p.containers[index]
+		if c.kind != ContainerRegular {
+			continue
+		}
+		if _, ok := seen[c.image]; ok {
+			continue
+		}
+		seen[c.image] = struct{}{}
+		images = append(images, c.image)
+	}
+	return strings.Join(images, ",")
+}
+
+// podLastRestart returns time since the most recent container termination which caused a restart.
+func podLastRestart(p *Pod) string {
+	if p.lastRestart.IsZero() {
+		return "-"
+	}
+	return translateTimestampSince(p.lastRestart)
+}
+
+func podOwnerName(p *Pod) string {
+	if p.owner.Name == "" {
+		return "-"
+	}
+	return strings.ToLower(p.owner.Kind) + "/" + p.owner.Name
+}
+
+// findPodColumn looks up a column by name, names are case insensitive and can contain spaces, '-' or '_', e.g.
+// 'LAST RESTART' or 'last_restart'.
+func findPodColumn(name string) (podColumn, bool) {
+	normalized := strings.ToLower(name)
+	for _, sep := range []string{" ", "-", "_"} {
+		normalized = strings.ReplaceAll(normalized, sep, "")
+	}
+	for _, column := range podColumns {
+		if column.name == normalized {
+			return column, true
+		}
+	}
+	return podColumn{}, false
+}
+
+// getPodColumns reads 'columns' setting, an ordered list of columns shown after NAME. CPU and MEMORY are appended when
+// metrics are enabled and they are not in the list.
+func getPodColumns(settings map[string]interface{}, showMetrics bool) ([]podColumn, error) {
+	names := defaultColumns
+	if value, ok := settings["columns"]; ok {
+		list, ok := value.([]interface{})
+		if !ok {
+			return nil, errors.New("columns should be a list of column names")
+		}
+		names = make([]string, 0, len(list))
+		for _, item := range list {
+			names = append(names, fmt.Sprintf("%v", item))
+		}
+	}
+
+	columns := make([]podColumn, 0, len(names)+2)
+	seen := make(map[string]struct{})
+	for _, name := range names {
+		column, ok := findPodColumn(name)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("invalid column '%v'", name))
+		}
+		if _, ok := seen[column.name]; ok {
+			continue
+		}
+		seen[column.name] = struct{}{}
+		columns = append(columns, column)
+	}
+	if showMetrics {
+		for _, name := range []string{ColumnCPU, ColumnMemory} {
+			if _, ok := seen[name]; !ok {
+				column, _ := findPodColumn(name)
+				columns = append(columns, column)
+			}
+		}
+	}
+	return columns, nil
+}
+
+// updateColumnWidths sets width of the name column and every pod column to their longest value in listed rows or
+// their title. It uses positions, so rows shown by a filter inside collapsed items are included.
+func (f *InfoFrame) updateColumnWidths() {
+	f.nameColWidth = NameColumnDefaultWidth
+	f.colWidths = make([]int, len(f.columns))
+	for index, column := range f.columns {
+		f.colWidths[index] = len(column.title) + ColumnSpacing
+	}
+
+	// State symbols are drawn after names, so they need space in the name column.
+	nameWidth := ColumnSpacing + activeTheme.symbolWidth()
+	for _, item := range f.positions {
+		switch i := item.(type) {
+		case *Namespace:
+			f.updateNameColWidth(NamespaceXOffset + nameWidth + len(i.DisplayName()))
+		case *PodGroup:
+			f.updateNameColWidth(PodGroupXOffset + nameWidth + len(i.name))
+		case *Pod:
+			f.updateNameColWidth(PodXOffset + nameWidth + len(i.name))
+			for index, column := range f.columns {
+				f.updateColumnWidthAt(index, column.value(i))
+			}
+		case *Container:
+			f.updateNameColWidth(ContainerXOffset + nameWidth + len(i.DisplayName()))
+			// Containers display only metrics in pod columns.
+			if f.hasMetricsColumns() {
+				cpu, memory := i.metricsCells()
+				f.updateColumnWidth(ColumnCPU, cpu)
+				f.updateColumnWidth(ColumnMemory, memory)
+			}
+		}
+		// Ready counts of namespaces and pod groups are shown in the first column.
+		if count, ok := f.readyCount(item); ok && len(f.columns) > 0 {
+			f.updateColumnWidthAt(0, count)
+		}
+	}
+}
+
+func (f *InfoFrame) updateNameColWidth(width int) {
+	if f.nameColWidth < width {
+		f.nameColWidth = width
+	}
+}
+
+func (f *InfoFrame) updateColumnWidthAt(index int, value string) {
+	if f.colWidths[index] < len(value)+ColumnSpacing {
+		f.colWidths[index] = len(value) + ColumnSpacing
+	}
+}
+
+func (f *InfoFrame) updateColumnWidth(name, value string) {
+	for index, column := range f.columns {
+		if column.name == name {
+			f.updateColumnWidthAt(index, value)
+		}
+	}
+}
+
+func (f *InfoFrame) hasMetricsColumns() bool {
+	_, cpu := f.columnPos(ColumnCPU)
+	_, memory := f.columnPos(ColumnMemory)
+	return cpu || memory
+}
+
+// columnPos returns x position of a column, false if the column is not displayed.
+func (f *InfoFrame) columnPos(name string) (int, bool) {
+	x := f.nameColWidth
+	for index, column := range f.columns {
+		if column.name == name {
+			return x, true
+		}
+		x += f.colWidths[index]
+	}
+	return 0, false
+}
+
+// readyCount returns ready/total pod counts of collapsed namespace and pod group rows, false for other rows.
+func (f *InfoFrame) readyCount(item Item) (string, bool) {
+	readyCount := 0
+	totalCount := 0
+	switch i := item.(type) {
+	case *Namespace:
+		if i.isExpanded && !f.isSummaryOnly(i) {
+			return "", false
+		}
+		for dIndex := range i.deployments {
+			totalCount += i.deployments[dIndex].countActivePods()
+			readyCount += i.deployments[dIndex].countReadyPods()
+		}
+	case *PodGroup:
+		if i.isExpanded {
+			return "", false
+		}
+		totalCount = i.countActivePods()
+		readyCount = i.countReadyPods()
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("%v/%v", readyCount, totalCount), true
+}
+
+// firstColumnEnd returns position after the first pod column, where ready counts of namespaces and pod groups end.
+func (f *InfoFrame) firstColumnEnd() int {
+	if len(f.colWidths) == 0 {
+		return f.nameColWidth + ReadyColumnDefaultWidth
+	}
+	return f.nameColWidth + f.colWidths[0]
+}
+
+// statusPos returns position of states of pod group and container rows: the STATUS column when it is displayed and
+// starts at or after minX, otherwise minX.
+func (f *InfoFrame) statusPos(minX int) int {
+	if x, ok := f.columnPos(ColumnStatus); ok && x >= minX {
+		return x
+	}
+	return minX
+}
+
+// columnsHeader returns titles of NAME and all pod columns aligned with their widths.
+func (f *InfoFrame) columnsHeader() string {
+	header := "NAME" + strings.Repeat(" ", f.nameColWidth-4)
+	for index, column := range f.columns {
+		header += column.title + strings.Repeat(" ", f.colWidths[index]-len(column.title))
+	}
+	return header
+}
+
+// contentWidth is the width of all columns, frame can be scrolled horizontally when it does not fit.
+func (f *InfoFrame) contentWidth() int {
+	width := f.nameColWidth
+	for _, w := range f.colWidths {
+		width += w
+	}
+	return width
+}
+
+// scrollColumns scrolls the frame horizontally by n characters, it is kept within content width.
+func (f *InfoFrame) scrollColumns(s tcell.Screen, n int) {
+	f.xOffset += n
+	f.refresh(s)
+}
+
+// clampXOffset keeps horizontal scroll within content, e.g. after columns get narrower.
+func (f *InfoFrame) clampXOffset() {
+	maxOffset := f.contentWidth() - f.width
+	if f.xOffset > maxOffset {
+		f.xOffset = maxOffset
+	}
+	if f.xOffset < 0 {
+		f.xOffset = 0
+	}
+}
+
+// drawCell draws value at content position x, shifted by horizontal scroll and clipped to the frame.
+func (f *InfoFrame) drawCell(s tcell.Screen, value string, x, yPos, length int, style tcell.Style) {
+	for i := 0; i < length; i++ {
+		screenX := x + i - f.xOffset
+		if screenX < 0 {
+			continue
+		}
+		if screenX >= f.width {
+			return
+		}
+		r := ' '
+		if i < len(value) {
+			r = rune(value[i])
+		}
+		s.SetContent(f.x+screenX, f.y+yPos, r, nil, style)
+	}
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPodColumns(t *testing.T) {
+	testTable := []struct {
+		name        string
+		settings    map[string]interface{}
+		showMetrics bool
+		expected    []string
+		expectErr   bool
+	}{
+		{
+			name:     "default",
+			settings: map[string]interface{}{},
+			expected: []string{ColumnReady, ColumnStatus, ColumnRestarts, ColumnAge},
+		},
+		{
+			name:        "default_with_metrics",
+			settings:    map[string]interface{}{},
+			showMetrics: true,
+			expected:    []string{ColumnReady, ColumnStatus, ColumnRestarts, ColumnAge, ColumnCPU, ColumnMemory},
+		},
+		{
+			name:     "custom_order_and_names",
+			settings: map[string]interface{}{"columns": []interface{}{"STATUS", "node", "Last Restart", "last_restart", "qos", "IP"}},
+			expected: []string{ColumnStatus, ColumnNode, ColumnLastRestart, ColumnQoS, ColumnIP},
+		},
+		{
+			name:        "metrics_keep_configured_position",
+			settings:    map[string]interface{}{"columns": []interface{}{"memory", "ready"}},
+			showMetrics: true,
+			expected:    []string{ColumnMemory, ColumnReady, ColumnCPU},
+		},
+		{
+			name:      "invalid_column",
+			settings:  map[string]interface{}{"columns": []interface{}{"ready", "color"}},
+			expectErr: true,
+		},
+		{
+			name:      "not_a_list",
+			settings:  map[string]interface{}{"columns": "ready"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			columns, err := getPodColumns(tc.settings, tc.showMetrics)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", columns)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			names := make([]string, 0, len(columns))
+			for _, column := range columns {
+				names = append(names, column.name)
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Errorf("Invalid columns. Want: %v, Got: %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestPodColumnValues(t *testing.T) {
+	pod := &Pod{
+		owner:   podOwner{Kind: "StatefulSet", Name: "db"},
+		details: podDetails{nodeName: "node-1", podIP: "10.0.0.1", qosClass: "Burstable"},
+		containers: []Container{
+			{kind: ContainerInit, image: "busybox:1"},
+			{kind: ContainerRegular, image: "postgres:13"},
+			{kind: ContainerRegular, image: "exporter:2"},
+			{kind: ContainerRegular, image: "postgres:13"},
+		},
+	}
+	expected := map[string]string{
+		ColumnNode:        "node-1",
+		ColumnIP:          "10.0.0.1",
+		ColumnQoS:         "Burstable",
+		ColumnImage:       "postgres:13,exporter:2",
+		ColumnOwner:       "statefulset/db",
+		ColumnLastRestart: "-",
+	}
+	for name, value := range expected {
+		column, _ := findPodColumn(name)
+		if got := column.value(pod); got != value {
+			t.Errorf("Invalid %v value. Want: %v, Got: %v", name, value, got)
+		}
+	}
+}
+
+func TestLastRestartTime(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC))
+	pod := &v1.Pod{Status: v1.PodStatus{
+		InitContainerStatuses: []v1.ContainerStatus{
+			{LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: earlier}}},
+		},
+		ContainerStatuses: []v1.ContainerStatus{
+			{},
+			{LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{FinishedAt: later}}},
+		},
+	}}
+
+	if got := lastRestartTime(pod); !got.Equal(later.Time) {
+		t.Errorf("Invalid last restart. Want: %v, Got: %v", later.Time, got)
+	}
+	if got := lastRestartTime(&v1.Pod{}); !got.IsZero() {
+		t.Errorf("Last restart should be zero without restarts, got %v", got)
+	}
+}
+
+func TestColumnWidthsAndScroll(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	_ = screen.Init()
+	screen.SetSize(40, 10)
+
+	columns, _ := getPodColumns(map[string]interface{}{"columns": []interface{}{"status", "node"}}, false)
+	frame := InfoFrame{width: 40, height: 5, columns: columns}
+	frame.nsItems = []Namespace{{name: "ns", context: "ctx", isExpanded: true, deployments: []*PodGroup{
+		{name: "web", isExpanded: true, pods: []Pod{
+			{name: "web-1", status: "CrashLoopBackOff", details: podDetails{nodeName: "node-with-long-name-1"}},
+		}},
+	}}}
+	frame.refresh(screen)
+
+	statusWidth := len("CrashLoopBackOff") + ColumnSpacing
+	nodeWidth := len("node-with-long-name-1") + ColumnSpacing
+	if !reflect.DeepEqual(frame.colWidths, []int{statusWidth, nodeWidth}) {
+		t.Errorf("Invalid column widths. Want: %v, Got: %v", []int{statusWidth, nodeWidth}, frame.colWidths)
+	}
+	if pos, _ := frame.columnPos(ColumnNode); pos != NameColumnDefaultWidth+statusWidth {
+		t.Errorf("Invalid node column position. Want: %v, Got: %v", NameColumnDefaultWidth+statusWidth, pos)
+	}
+	if !strings.HasPrefix(frame.podHeader.value, "NAME") {
+		t.Errorf("Header should start with NAME, got '%v'", frame.podHeader.value)
+	}
+
+	// Content is wider than the frame, scrolling is limited to show the last column at the right border.
+	frame.scrollColumns(screen, 1000)
+	maxOffset := frame.contentWidth() - frame.width
+	if frame.xOffset != maxOffset {
+		t.Errorf("Invalid horizontal offset. Want: %v, Got: %v", maxOffset, frame.xOffset)
+	}
+	nodeX, _ := frame.columnPos(ColumnNode)
+	if r, _, _, _ := screen.GetContent(nodeX-frame.xOffset, 2); r != 'n' {
+		t.Errorf("Node column should be visible after scroll, got '%c'", r)
+	}
+
+	frame.scrollColumns(screen, -1000)
+	if frame.xOffset != 0 {
+		t.Errorf("Invalid horizontal offset. Want: 0, Got: %v", frame.xOffset)
+	}
+}
+
+func TestStatusPositionFollowsColumns(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	_ = screen.Init()
+	screen.SetSize(120, 10)
+
+	columns, _ := getPodColumns(map[string]interface{}{"columns": []interface{}{"node", "status"}}, false)
+	frame := InfoFrame{width: 120, height: 5, columns: columns}
+	frame.nsItems = []Namespace{{name: "ns", context: "ctx", isExpanded: true, deployments: []*PodGroup{
+		{name: "web", isExpanded: true, workload: &workloadStatus{Kind: KindDeployment, Desired: 2}, pods: []Pod{
+			{name: "web-1", status: "Running", isExpanded: true, details: podDetails{nodeName: "node-1"},
+				containers: []Container{{name: "app", state: "Running"}}},
+		}},
+	}}}
+	frame.refresh(screen)
+
+	statusX, _ := frame.columnPos(ColumnStatus)
+	if statusX == frame.nameColWidth {
+		t.Fatalf("Status should not be the first column")
+	}
+	testTable := []struct {
+		name     string
+		y        int
+		expected rune
+	}{
+		{name: "group_replica_counts", y: 1, expected: 'd'},
+		{name: "pod_status", y: 2, expected: 'R'},
+		{name: "container_state", y: 3, expected: 'R'},
+	}
+	for _, tc := range testTable {
+		if r, _, _, _ := screen.GetContent(statusX, tc.y); r != tc.expected {
+			t.Errorf("%v should start in status column. Want: '%c', Got: '%c'", tc.name, tc.expected, r)
+		}
+	}
+}
+
+func TestNameWidthFromPositions(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	_ = screen.Init()
+	screen.SetSize(120, 10)
+
+	longPod := "pod-with-a-very-long-name-matched-by-filter"
+	longContainer := "container-with-a-very-long-name"
+	columns, _ := getPodColumns(map[string]interface{}{}, false)
+	frame := InfoFrame{width: 120, height: 10, columns: columns, filter: newFilter("long")}
+	frame.nsItems = []Namespace{{name: "ns", context: "ctx", deployments: []*PodGroup{
+		{name: "web", pods: []Pod{{name: longPod, status: "Running", isExpanded: true,
+			containers: []Container{{name: longContainer, version: "1"}}}}},
+	}}}
+	frame.refresh(screen)
+
+	container := Container{name: longContainer, version: "1"}
+	minWidth := ContainerXOffset + len(container.DisplayName()) + ColumnSpacing
+	if podWidth := PodXOffset + len(longPod) + ColumnSpacing; podWidth > minWidth {
+		minWidth = podWidth
+	}
+	if frame.nameColWidth < minWidth {
+		t.Errorf("Name column should fit pods and containers shown by filter. Want at least: %v, Got: %v", minWidth, frame.nameColWidth)
+	}
+}
@@ -21,6 +21,7 @@ type GuiItemInfo struct {
 	Context   string
 	Namespace string
 	Group     string
+	Kind      string
 	Pod       string
 	Container string
 }
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"strings"
+	"time"
+)
+
+// ContainerDetail is a single label/value row displayed under an expanded container.
+type ContainerDetail struct {
+	label     string
+	value     string
+	container *Container
+}
+
+func (cd *ContainerDetail) Type() Type {
+	return TypeContainerDetail
+}
+
+func (cd *ContainerDetail) Level() int {
+	return 4
+}
+
+func (cd *ContainerDetail) Expanded(b bool) {
+}
+
+func (cd *ContainerDetail) IsExpanded() bool {
+	return false
+}
+
+func (cd *ContainerDetail) DisplayName() string {
+	return fmt.Sprintf("%v: %v", cd.label, cd.value)
+}
+
+// toContainerDetails builds detail rows from container status, memory limit is added to OOMKilled terminations.
+func toContainerDetails(cs *v1.ContainerStatus, resources containerResources) []ContainerDetail {
+	details := make([]ContainerDetail, 0)
+	add := func(label, value string) {
+		details = append(details, ContainerDetail{label: label, value: value})
+	}
+
+	add("Image", cs.Image)
+	if cs.ImageID != "" {
+		add("Image ID", cs.ImageID)
+	}
+	switch {
+	case cs.State.Running != nil:
+		add("Started", formatTime(cs.State.Running.StartedAt.Time))
+	case cs.State.Terminated != nil:
+		add("Started", formatTime(cs.State.Terminated.StartedAt.Time))
+		add("Terminated", formatTermination(cs.State.Terminated, resources))
+	case cs.State.Waiting != nil:
+		add("Waiting", cs.State.Waiting.Reason)
+	}
+	add("Restarts", fmt.Sprintf("%d", cs.RestartCount))
+	if cs.LastTerminationState.Terminated != nil {
+		add("Last termination", formatTermination(cs.LastTerminationState.Terminated, resources))
+	}
+
+	message := ""
+	if cs.State.Waiting != nil {
+		message = cs.State.Waiting.Message
+	} else if cs.State.Terminated != nil {
+		message = cs.State.Terminated.Message
+	}
+	if message != "" {
+		// Termination messages can be multiline, they are displayed on a single row.
+		add("Message", strings.Join(strings.Fields(message), " "))
+	}
+	return details
+}
+
+// formatTermination returns e.g. 'OOMKilled, exit code 137, finished 2021-02-01 10:00:00 (5m ago), memory limit 256Mi'.
+func formatTermination(t *v1.ContainerStateTerminated, resources containerResources) string {
+	reason := t.Reason
+	if reason == "" {
+		reason = "Terminated"
+	}
+	result := fmt.Sprintf("%v, exit code %d", reason, t.ExitCode)
+	if t.Signal != 0 {
+		result += fmt.Sprintf(", signal %d", t.Signal)
+	}
+	if !t.FinishedAt.IsZero() {
+		result += ", finished " + formatTime(t.FinishedAt.Time)
+	}
+	if t.Reason == "OOMKilled" && resources.memoryLimit > 0 {
+		result += ", memory limit " + formatMemory(resources.memoryLimit)
+	}
+	return result
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return fmt.Sprintf("%v (%v ago)", t.Local().Format("2006-01-02 15:04:05"), translateTimestampSince(t))
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestToContainerDetails(t *testing.T) {
+	cs := v1.ContainerStatus{
+		Name:         "app",
+		Image:        "app:1.0",
+		ImageID:      "docker-pullable://app@sha256:abc",
+		RestartCount: 2,
+		State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+			Reason:  "CrashLoopBackOff",
+			Message: "back-off 20s\nrestarting failed container",
+		}},
+		LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			Reason:   "OOMKilled",
+			ExitCode: 137,
+		}},
+	}
+
+	details := toContainerDetails(&cs, containerResources{memoryLimit: 256 << 20})
+
+	expected := []string{
+		"Image: app:1.0",
+		"Image ID: docker-pullable://app@sha256:abc",
+		"Waiting: CrashLoopBackOff",
+		"Restarts: 2",
+		"Last termination: OOMKilled, exit code 137, memory limit 256Mi",
+		"Message: back-off 20s restarting failed container",
+	}
+	if len(details) != len(expected) {
+		t.Fatalf("Invalid number of details. Want: %v, Got: %v", len(expected), len(details))
+	}
+	for index, want := range expected {
+		if got := details[index].DisplayName(); got != want {
+			t.Errorf("Invalid detail row %v. Want: %v, Got: %v", index, want, got)
+		}
+	}
+}
+
+func TestContainerExpandedSurvivesUpdate(t *testing.T) {
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			{Name: "app", Image: "app:1.0", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+		}},
+	}
+	plr := PodListResult{context: "dev", namespace: "ns", PodList: v1.PodList{Items: []v1.Pod{pod}}}
+
+	f := InfoFrame{grouping: defaultGroupingRules()}
+	f.updateNamespaces([]PodListResult{plr})
+	f.nsItems[0].Expanded(true)
+	f.nsItems[0].deployments[0].Expanded(true)
+	f.nsItems[0].deployments[0].pods[0].Expanded(true)
+	f.nsItems[0].deployments[0].pods[0].containers[0].Expanded(true)
+
+	f.updateNamespaces([]PodListResult{plr})
+	f.updatePositions()
+
+	details := 0
+	for _, item := range f.positions {
+		if item.Type() == TypeContainerDetail {
+			details++
+			if item.(*ContainerDetail).container.name != "app" {
+				t.Errorf("Detail row points to invalid container %v", item.(*ContainerDetail).container.name)
+			}
+		}
+	}
+	if details == 0 {
+		t.Errorf("Container detail rows should be displayed after update")
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/JLevconoks/k8ConsoleViewer/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"strings"
+	"sync"
+)
+
+const describeHelp = "Esc close  Tab YAML/describe  c copy  w wrap  / search  arrows/PgUp/PgDn scroll"
+
+// DescribeFrame shows an object as YAML or as a describe like summary with its events in a full screen TextView.
+type DescribeFrame struct {
+	sync.Mutex
+	TextView
+	visible bool
+	ref     resourceRef
+	// showYAML switches between result.yaml and result.describe.
+	showYAML bool
+	result   describeResult
+	loading  bool
+	err      error
+	// message is a short result of the last action, e.g. copying to clipboard.
+	message   string
+	cancel    context.CancelFunc
+	clipboard *clipboard.Clipboard
+}
+
+func NewDescribeFrame(winWidth, winHeight int) *DescribeFrame {
+	df := &DescribeFrame{showYAML: true}
+	df.resize(winWidth, winHeight)
+	return df
+}
+
+// open shows the frame and fetches the object in background, frame is redrawn once it is loaded.
+func (df *DescribeFrame) open(s tcell.Screen, describer objectDescriber, ref resourceRef) {
+	df.Lock()
+	defer df.Unlock()
+
+	if df.cancel != nil {
+		df.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	df.cancel = cancel
+	df.visible = true
+	df.ref = ref
+	df.result = describeResult{}
+	df.loading = true
+	df.err = nil
+	df.message = ""
+	df.search = nil
+	df.searchInput = false
+	df.searchText = ""
+	df.setLines()
+	df.draw(s)
+	s.Show()
+
+	go func() {
+		result, err := describer.describe(ctx, ref)
+		df.Lock()
+		defer df.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		df.result = result
+		df.err = err
+		df.loading = false
+		df.setLines()
+		df.draw(s)
+		s.Show()
+	}()
+}
+
+func (df *DescribeFrame) close() {
+	df.Lock()
+	defer df.Unlock()
+
+	if df.cancel != nil {
+		df.cancel()
+		df.cancel = nil
+	}
+	df.visible = false
+}
+
+// setLines replaces displayed lines with the current representation, view is scrolled to the top.
+func (df *DescribeFrame) setLines() {
+	var values []string
+	switch {
+	case df.loading:
+		values = []string{"Loading..."}
+	case df.err != nil:
+		values = []string{"Error: " + df.err.Error()}
+	case df.showYAML:
+		values = df.result.yaml
+	default:
+		values = df.result.describe
+	}
+
+	df.lines = make([]textLine, 0, len(values))
+	for _, value := range values {
+		df.lines = append(df.lines, textLine{text: strings.ReplaceAll(value, "\t", "    "), style: tcell.StyleDefault})
+	}
+	df.topRow = 0
+	df.xOffset = 0
+}
+
+// buffer returns currently displayed representation as a single string.
+func (df *DescribeFrame) buffer() string {
+	if df.showYAML {
+		return strings.Join(df.result.yaml, "\n") + "\n"
+	}
+	return strings.Join(df.result.describe, "\n") + "\n"
+}
+
+// handleKey handles all keys while the frame is visible, returns false when the frame was closed.
+func (df *DescribeFrame) handleKey(s tcell.Screen, ev *tcell.EventKey) bool {
+	df.Lock()
+	df.message = ""
+	switch {
+	case df.searchInput:
+		df.TextView.handleKey(ev)
+	case ev.Key() == tcell.KeyEscape && df.search != nil:
+		df.search = nil
+		df.searchText = ""
+	case ev.Key() == tcell.KeyEscape || (ev.Key() == tcell.KeyRune && ev.Rune() == 'q'):
+		df.Unlock()
+		df.close()
+		return false
+	case ev.Key() == tcell.KeyTab:
+		df.showYAML = !df.showYAML
+		df.setLines()
+	case ev.Key() == tcell.KeyRune && ev.Rune() == 'c':
+		df.copyBuffer()
+	default:
+		df.TextView.handleKey(ev)
+	}
+	df.draw(s)
+	df.Unlock()
+	s.Show()
+	return true
+}
+
+func (df *DescribeFrame) copyBuffer() {
+	if df.loading || df.err != nil {
+		return
+	}
+	if err := df.clipboard.Copy(df.buffer()); err != nil {
+		df.message = "Error: " + err.Error()
+		return
+	}
+	df.message = fmt.Sprintf("Copied %d lines to clipboard", len(df.lines))
+}
+
+func (df *DescribeFrame) resizeFrame(winWidth, winHeight int) {
+	df.Lock()
+	defer df.Unlock()
+	df.resize(winWidth, winHeight)
+	df.scroll(0)
+}
+
+func (df *DescribeFrame) scrollView(s tcell.Screen, n int) {
+	df.Lock()
+	df.scroll(n)
+	df.draw(s)
+	df.Unlock()
+	s.Show()
+}
+
+func (df *DescribeFrame) redraw(s tcell.Screen) {
+	df.Lock()
+	defer df.Unlock()
+	df.draw(s)
+}
+
+func (df *DescribeFrame) draw(s tcell.Screen) {
+	mode := "Describe"
+	if df.showYAML {
+		mode = "YAML"
+	}
+	title := fmt.Sprintf("%v: %v (%v/%v)", mode, df.ref, df.ref.context, df.ref.namespace)
+	if df.message != "" {
+		title += "  " + df.message
+	}
+	df.TextView.draw(s, title, describeHelp)
+}
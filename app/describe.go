@@ -0,0 +1,345 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	KindNamespace = "Namespace"
+	KindPod       = "Pod"
+	// describeFieldWidth aligns values in describe output, the same way kubectl describe does.
+	describeFieldWidth = 18
+)
+
+// resourceRef identifies an object displayed in DescribeFrame.
+type resourceRef struct {
+	context   string
+	namespace string
+	kind      string
+	name      string
+}
+
+func (r resourceRef) String() string {
+	return fmt.Sprintf("%v/%v", strings.ToLower(r.kind), r.name)
+}
+
+// describeResult holds both representations of an object, so DescribeFrame can switch between them without fetching.
+type describeResult struct {
+	yaml     []string
+	describe []string
+}
+
+// objectDescriber is implemented by Client, it is not available when app is replaying a record file.
+type objectDescriber interface {
+	describe(ctx context.Context, ref resourceRef) (describeResult, error)
+}
+
+// describeTarget returns object which is described for the item: namespace, top level owner of the pod group or pod.
+// Containers and their details describe their pod.
+func describeTarget(item Item) (resourceRef, error) {
+	switch i := item.(type) {
+	case *Namespace:
+		return resourceRef{context: i.context, namespace: i.name, kind: KindNamespace, name: i.name}, nil
+	case *PodGroup:
+		owner := i.pods[0].owner
+		if owner.Kind == "" {
+			return resourceRef{}, errors.New(fmt.Sprintf("group %v has no owner, select a pod instead", i.name))
+		}
+		return resourceRef{context: i.namespace.context, namespace: i.namespace.name, kind: owner.Kind, name: owner.Name}, nil
+	case *Pod:
+		return podRef(i), nil
+	case *Container:
+		return podRef(i.pod), nil
+	case *ContainerDetail:
+		return podRef(i.container.pod), nil
+	default:
+		return resourceRef{}, errors.New("select a namespace, group or pod to describe")
+	}
+}
+
+func podRef(p *Pod) resourceRef {
+	ns := p.podGroup.namespace
+	return resourceRef{context: ns.context, namespace: ns.name, kind: KindPod, name: p.name}
+}
+
+func (k8Client Client) describe(ctx context.Context, ref resourceRef) (describeResult, error) {
+	obj, err := k8Client.getObject(ctx, ref)
+	if err != nil {
+		return describeResult{}, err
+	}
+	yamlLines, err := toYAML(obj, ref.kind)
+	if err != nil {
+		return describeResult{}, err
+	}
+
+	// Object itself is more important than its events, so events error is only shown in place of events.
+	events, eventsErr := k8Client.objectEvents(ctx, ref)
+	return describeResult{yaml: yamlLines, describe: describeObject(obj, events, eventsErr)}, nil
+}
+
+func (k8Client Client) getObject(ctx context.Context, ref resourceRef) (runtime.Object, error) {
+	clientSet, ok := k8Client.k8ClientSets[ref.context]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("no client for context %v", ref.context))
+	}
+	getOptions := metav1.GetOptions{}
+	switch ref.kind {
+	case KindNamespace:
+		return clientSet.CoreV1().Namespaces().Get(ctx, ref.name, getOptions)
+	case KindPod:
+		return clientSet.CoreV1().Pods(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindDeployment:
+		return clientSet.AppsV1().Deployments(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindStatefulSet:
+		return clientSet.AppsV1().StatefulSets(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindDaemonSet:
+		return clientSet.AppsV1().DaemonSets(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindReplicaSet:
+		return clientSet.AppsV1().ReplicaSets(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindJob:
+		return clientSet.BatchV1().Jobs(ref.namespace).Get(ctx, ref.name, getOptions)
+	case KindCronJob:
+		return clientSet.BatchV1beta1().CronJobs(ref.namespace).Get(ctx, ref.name, getOptions)
+	default:
+		return nil, errors.New(fmt.Sprintf("describing %v is not supported", ref.kind))
+	}
+}
+
+// objectEvents returns events of the object sorted by last seen time, oldest first as in kubectl describe.
+func (k8Client Client) objectEvents(ctx context.Context, ref resourceRef) ([]v1.Event, error) {
+	selector := fields.Set{"involvedObject.kind": ref.kind, "involvedObject.name": ref.name}.AsSelector().String()
+	list, err := k8Client.k8ClientSets[ref.context].CoreV1().Events(ref.namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	events := list.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventLastSeen(&events[i]).Before(eventLastSeen(&events[j]))
+	})
+	return events, nil
+}
+
+// apiVersions are used to fill TypeMeta, which is empty in objects returned by typed clients.
+var apiVersions = map[string]string{
+	KindNamespace:   "v1",
+	KindPod:         "v1",
+	KindDeployment:  "apps/v1",
+	KindStatefulSet: "apps/v1",
+	KindDaemonSet:   "apps/v1",
+	KindReplicaSet:  "apps/v1",
+	KindJob:         "batch/v1",
+	KindCronJob:     "batch/v1beta1",
+}
+
+// toYAML returns object as YAML lines without managedFields, which are rarely useful and usually the longest part.
+func toYAML(obj runtime.Object, kind string) ([]string, error) {
+	obj = obj.DeepCopyObject()
+	if accessor, ok := obj.(metav1.ObjectMetaAccessor); ok {
+		accessor.GetObjectMeta().SetManagedFields(nil)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(schema.FromAPIVersionAndKind(apiVersions[kind], kind))
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n"), nil
+}
+
+// describeObject returns kubectl describe like summary of the object followed by its events.
+func describeObject(obj runtime.Object, events []v1.Event, eventsErr error) []string {
+	d := describeWriter{}
+	switch o := obj.(type) {
+	case *v1.Namespace:
+		d.meta(KindNamespace, &o.ObjectMeta)
+		d.field("Status", string(o.Status.Phase))
+	case *v1.Pod:
+		describePod(&d, o)
+	case *appsv1.Deployment:
+		d.meta(KindDeployment, &o.ObjectMeta)
+		d.field("Selector", metav1.FormatLabelSelector(o.Spec.Selector))
+		d.field("Replicas", fmt.Sprintf("%d desired | %d updated | %d ready | %d available | %d unavailable",
+			replicas(o.Spec.Replicas), o.Status.UpdatedReplicas, o.Status.ReadyReplicas, o.Status.AvailableReplicas, o.Status.UnavailableReplicas))
+		d.field("Strategy", string(o.Spec.Strategy.Type))
+		d.images(o.Spec.Template.Spec.Containers)
+		d.conditions(deploymentConditions(o.Status.Conditions))
+	case *appsv1.StatefulSet:
+		d.meta(KindStatefulSet, &o.ObjectMeta)
+		d.field("Selector", metav1.FormatLabelSelector(o.Spec.Selector))
+		d.field("Replicas", fmt.Sprintf("%d desired | %d current | %d updated | %d ready",
+			replicas(o.Spec.Replicas), o.Status.CurrentReplicas, o.Status.UpdatedReplicas, o.Status.ReadyReplicas))
+		d.field("Service name", o.Spec.ServiceName)
+		d.field("Update strategy", string(o.Spec.UpdateStrategy.Type))
+		d.images(o.Spec.Template.Spec.Containers)
+	case *appsv1.DaemonSet:
+		d.meta(KindDaemonSet, &o.ObjectMeta)
+		d.field("Selector", metav1.FormatLabelSelector(o.Spec.Selector))
+		d.field("Nodes", fmt.Sprintf("%d desired | %d current | %d updated | %d ready | %d available",
+			o.Status.DesiredNumberScheduled, o.Status.CurrentNumberScheduled, o.Status.UpdatedNumberScheduled, o.Status.NumberReady, o.Status.NumberAvailable))
+		d.field("Update strategy", string(o.Spec.UpdateStrategy.Type))
+		d.images(o.Spec.Template.Spec.Containers)
+	case *appsv1.ReplicaSet:
+		d.meta(KindReplicaSet, &o.ObjectMeta)
+		d.field("Selector", metav1.FormatLabelSelector(o.Spec.Selector))
+		d.field("Replicas", fmt.Sprintf("%d desired | %d current | %d ready | %d available",
+			replicas(o.Spec.Replicas), o.Status.Replicas, o.Status.ReadyReplicas, o.Status.AvailableReplicas))
+		d.images(o.Spec.Template.Spec.Containers)
+	case *batchv1.Job:
+		d.meta(KindJob, &o.ObjectMeta)
+		d.field("Completions", fmt.Sprintf("%d/%d", o.Status.Succeeded, replicas(o.Spec.Completions)))
+		d.field("Pods", fmt.Sprintf("%d active | %d succeeded | %d failed", o.Status.Active, o.Status.Succeeded, o.Status.Failed))
+		if o.Status.StartTime != nil {
+			d.field("Start time", formatDescribeTime(o.Status.StartTime.Time))
+		}
+		if o.Status.CompletionTime != nil {
+			d.field("Completed at", formatDescribeTime(o.Status.CompletionTime.Time))
+		}
+		d.images(o.Spec.Template.Spec.Containers)
+	case *batchv1beta1.CronJob:
+		d.meta(KindCronJob, &o.ObjectMeta)
+		d.field("Schedule", o.Spec.Schedule)
+		d.field("Suspend", fmt.Sprintf("%v", o.Spec.Suspend != nil && *o.Spec.Suspend))
+		d.field("Active jobs", fmt.Sprintf("%d", len(o.Status.Active)))
+		if o.Status.LastScheduleTime != nil {
+			d.field("Last schedule", formatDescribeTime(o.Status.LastScheduleTime.Time))
+		}
+		d.images(o.Spec.JobTemplate.Spec.Template.Spec.Containers)
+	}
+	d.events(events, eventsErr)
+	return d.lines
+}
+
+func describePod(d *describeWriter, p *v1.Pod) {
+	d.meta(KindPod, &p.ObjectMeta)
+	status, _, _, _, _ := podStats(p)
+	d.field("Status", status)
+
+	details := toPodDetails(p)
+	d.field("Node", details.nodeName)
+	d.field("Pod IP", details.podIP)
+	d.field("Host IP", details.hostIP)
+	d.field("QoS class", details.qosClass)
+	d.field("Priority class", details.priorityClass)
+	d.field("Service account", details.serviceAccount)
+
+	d.lines = append(d.lines, "", "Containers:")
+	pod := toPod(*p, &PodGroup{})
+	for cIndex := range pod.containers {
+		c := &pod.containers[cIndex]
+		d.lines = append(d.lines,
+			fmt.Sprintf("  %v%v:", c.marker(), c.name),
+			fmt.Sprintf("    Image:     %v", c.image),
+			fmt.Sprintf("    State:     %v", c.StatusString()),
+			fmt.Sprintf("    Ready:     %v", c.ready),
+			fmt.Sprintf("    Restarts:  %d", c.restarts),
+		)
+	}
+
+	d.lines = append(d.lines, "", "Conditions:")
+	for _, c := range details.conditions {
+		d.lines = append(d.lines, fmt.Sprintf("  %-16v %v", c.conditionType, c.status))
+	}
+	d.lines = append(d.lines, "", fmt.Sprintf("Tolerations (%d):", len(details.tolerations)))
+	for _, t := range details.tolerations {
+		d.lines = append(d.lines, "  "+t)
+	}
+}
+
+// describeWriter builds describe output with values aligned after field names.
+type describeWriter struct {
+	lines []string
+}
+
+func (d *describeWriter) field(name, value string) {
+	d.lines = append(d.lines, fmt.Sprintf("%-*v%v", describeFieldWidth, name+":", valueOrDash(value)))
+}
+
+func (d *describeWriter) meta(kind string, meta *metav1.ObjectMeta) {
+	d.field("Name", meta.Name)
+	if meta.Namespace != "" {
+		d.field("Namespace", meta.Namespace)
+	}
+	d.field("Kind", kind)
+	d.field("Created", formatDescribeTime(meta.CreationTimestamp.Time))
+	d.keyValues("Labels", meta.Labels)
+	d.keyValues("Annotations", meta.Annotations)
+}
+
+// keyValues writes sorted key=value pairs, one per line, the first one on the line with the field name.
+func (d *describeWriter) keyValues(name string, m map[string]string) {
+	values := sortedKeyValues(m)
+	if len(values) == 0 {
+		d.field(name, "")
+		return
+	}
+	d.field(name, strings.TrimSpace(values[0]))
+	for _, value := range values[1:] {
+		d.lines = append(d.lines, strings.Repeat(" ", describeFieldWidth)+strings.TrimSpace(value))
+	}
+}
+
+func (d *describeWriter) images(containers []v1.Container) {
+	images := make([]string, 0, len(containers))
+	for _, c := range containers {
+		images = append(images, fmt.Sprintf("%v=%v", c.Name, c.Image))
+	}
+	d.field("Images", strings.Join(images, ", "))
+}
+
+func (d *describeWriter) conditions(conditions []string) {
+	d.lines = append(d.lines, "", "Conditions:")
+	for _, c := range conditions {
+		d.lines = append(d.lines, "  "+c)
+	}
+}
+
+func (d *describeWriter) events(events []v1.Event, err error) {
+	d.lines = append(d.lines, "", "Events:")
+	if err != nil {
+		d.lines = append(d.lines, "  Error: "+err.Error())
+		return
+	}
+	if len(events) == 0 {
+		d.lines = append(d.lines, "  <none>")
+		return
+	}
+	d.lines = append(d.lines, fmt.Sprintf("  %-8v %-24v %-8v %-6v %v", "TYPE", "REASON", "AGE", "COUNT", "MESSAGE"))
+	for index := range events {
+		e := &events[index]
+		count := e.Count
+		if count == 0 {
+			count = 1
+		}
+		d.lines = append(d.lines, fmt.Sprintf("  %-8v %-24v %-8v %-6v %v",
+			e.Type, e.Reason, translateTimestampSince(eventLastSeen(e)), count, strings.TrimSpace(e.Message)))
+	}
+}
+
+func deploymentConditions(conditions []appsv1.DeploymentCondition) []string {
+	result := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		result = append(result, fmt.Sprintf("%-16v %-6v %v", c.Type, c.Status, c.Reason))
+	}
+	return result
+}
+
+func formatDescribeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%v (%v ago)", t.Format(time.RFC1123Z), translateTimestampSince(t))
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDescribeTarget(t *testing.T) {
+	ns := &Namespace{name: "ns1", context: "context"}
+	pg := &PodGroup{name: "web", namespace: ns}
+	pg.pods = []Pod{{name: "web-1", podGroup: pg, owner: podOwner{Kind: KindDeployment, Name: "web"}}}
+	pg.pods[0].containers = []Container{{name: "app", pod: &pg.pods[0]}}
+	noOwner := &PodGroup{name: "_", namespace: ns, pods: []Pod{{name: "static"}}}
+
+	testTable := []struct {
+		name     string
+		item     Item
+		expected resourceRef
+		wantErr  bool
+	}{
+		{name: "namespace", item: ns, expected: resourceRef{"context", "ns1", KindNamespace, "ns1"}},
+		{name: "pod_group", item: pg, expected: resourceRef{"context", "ns1", KindDeployment, "web"}},
+		{name: "pod", item: &pg.pods[0], expected: resourceRef{"context", "ns1", KindPod, "web-1"}},
+		{name: "container", item: &pg.pods[0].containers[0], expected: resourceRef{"context", "ns1", KindPod, "web-1"}},
+		{name: "group_without_owner", item: noOwner, wantErr: true},
+		{name: "events", item: &ns.events, wantErr: true},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := describeTarget(tc.item)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error result. Want error: %v, Got: %v", tc.wantErr, err)
+			}
+			if ref != tc.expected {
+				t.Errorf("Invalid ref. Want: %v, Got: %v", tc.expected, ref)
+			}
+		})
+	}
+}
+
+func TestClientDescribe(t *testing.T) {
+	replicas := int32(3)
+	clientSet := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "web",
+				Namespace:     "ns1",
+				Labels:        map[string]string{"app": "web", "tier": "frontend"},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "web:1.0"}}}},
+			},
+			Status: appsv1.DeploymentStatus{UpdatedReplicas: 3, ReadyReplicas: 2, AvailableReplicas: 2, UnavailableReplicas: 1},
+		},
+		&v1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web.1", Namespace: "ns1"},
+			InvolvedObject: v1.ObjectReference{Kind: KindDeployment, Name: "web"},
+			Type:           "Normal",
+			Reason:         "ScalingReplicaSet",
+			Message:        "Scaled up replica set web-abc to 3",
+			Count:          2,
+		},
+	)
+	client := Client{k8ClientSets: clientSetMap{"context": clientSet}}
+
+	result, err := client.describe(context.Background(), resourceRef{"context", "ns1", KindDeployment, "web"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	yamlText := strings.Join(result.yaml, "\n")
+	for _, expected := range []string{"apiVersion: apps/v1", "kind: Deployment", "image: web:1.0"} {
+		if !strings.Contains(yamlText, expected) {
+			t.Errorf("YAML should contain %q, got:\n%v", expected, yamlText)
+		}
+	}
+	if strings.Contains(yamlText, "managedFields") {
+		t.Errorf("YAML should not contain managedFields, got:\n%v", yamlText)
+	}
+
+	describeText := strings.Join(result.describe, "\n")
+	for _, expected := range []string{
+		"Name:             web",
+		"Labels:           app=web\n                  tier=frontend",
+		"Replicas:         3 desired | 3 updated | 2 ready | 2 available | 1 unavailable",
+		"Images:           app=web:1.0",
+		"ScalingReplicaSet",
+	} {
+		if !strings.Contains(describeText, expected) {
+			t.Errorf("Describe should contain %q, got:\n%v", expected, describeText)
+		}
+	}
+
+	if _, err := client.describe(context.Background(), resourceRef{"context", "ns1", KindPod, "missing"}); err == nil {
+		t.Errorf("Expected error for missing pod")
+	}
+}
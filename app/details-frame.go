@@ -0,0 +1,194 @@
+package app
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	v1 "k8s.io/api/core/v1"
+	"sort"
+	"time"
+)
+
+const (
+	DetailsFrameMinWidth = 40
+	DetailsItemXOffset   = 2
+)
+
+// podDetails holds pod information displayed in DetailsFrame, it is built together with Pod on every update.
+type podDetails struct {
+	nodeName       string
+	podIP          string
+	hostIP         string
+	qosClass       string
+	priorityClass  string
+	serviceAccount string
+	conditions     []podCondition
+	tolerations    []string
+	labels         map[string]string
+	annotations    map[string]string
+}
+
+type podCondition struct {
+	conditionType      string
+	status             string
+	lastTransitionTime time.Time
+}
+
+func toPodDetails(p *v1.Pod) podDetails {
+	details := podDetails{
+		nodeName:       p.Spec.NodeName,
+		podIP:          p.Status.PodIP,
+		hostIP:         p.Status.HostIP,
+		qosClass:       string(p.Status.QOSClass),
+		priorityClass:  p.Spec.PriorityClassName,
+		serviceAccount: p.Spec.ServiceAccountName,
+		labels:         p.Labels,
+		annotations:    p.Annotations,
+	}
+	for _, c := range p.Status.Conditions {
+		details.conditions = append(details.conditions, podCondition{
+			conditionType:      string(c.Type),
+			status:             string(c.Status),
+			lastTransitionTime: c.LastTransitionTime.Time,
+		})
+	}
+	for index := range p.Spec.Tolerations {
+		details.tolerations = append(details.tolerations, tolerationSummary(&p.Spec.Tolerations[index]))
+	}
+	return details
+}
+
+// tolerationSummary returns toleration in the same format as kubectl describe, e.g.
+// 'node.kubernetes.io/not-ready:NoExecute op=Exists for 300s'.
+func tolerationSummary(t *v1.Toleration) string {
+	summary := t.Key
+	if t.Value != "" {
+		summary += "=" + t.Value
+	}
+	if t.Effect != "" {
+		summary += ":" + string(t.Effect)
+	}
+	if t.Operator == v1.TolerationOpExists && t.Value == "" {
+		if summary == "" {
+			summary = "op=Exists"
+		} else {
+			summary += " op=Exists"
+		}
+	}
+	if t.TolerationSeconds != nil {
+		summary += fmt.Sprintf(" for %ds", *t.TolerationSeconds)
+	}
+	return summary
+}
+
+func (d *podDetails) lines() []string {
+	lines := []string{
+		"Node: " + valueOrDash(d.nodeName),
+		"Pod IP: " + valueOrDash(d.podIP),
+		"Host IP: " + valueOrDash(d.hostIP),
+		"QoS class: " + valueOrDash(d.qosClass),
+		"Priority class: " + valueOrDash(d.priorityClass),
+		"Service account: " + valueOrDash(d.serviceAccount),
+		"",
+		"Conditions:",
+	}
+	for _, c := range d.conditions {
+		lines = append(lines, fmt.Sprintf("  %v %v (%v ago)", c.conditionType, c.status, translateTimestampSince(c.lastTransitionTime)))
+	}
+	lines = append(lines, "", fmt.Sprintf("Tolerations (%d):", len(d.tolerations)))
+	for _, t := range d.tolerations {
+		lines = append(lines, "  "+t)
+	}
+	lines = append(lines, "", "Labels:")
+	lines = append(lines, sortedKeyValues(d.labels)...)
+	lines = append(lines, "", "Annotations:")
+	lines = append(lines, sortedKeyValues(d.annotations)...)
+	return lines
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func sortedKeyValues(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, fmt.Sprintf("  %v=%v", key, m[key]))
+	}
+	return result
+}
+
+// DetailsFrame is a toggleable panel on the right side of InfoFrame, showing details of the pod under cursor.
+type DetailsFrame struct {
+	x, y          int
+	width, height int
+	visible       bool
+}
+
+func NewDetailsFrame(winWidth, winHeight int) *DetailsFrame {
+	frame := &DetailsFrame{y: MainFrameStartY}
+	frame.resize(winWidth, winHeight)
+	return frame
+}
+
+// sidePanelWidth returns width taken from InfoFrame, 0 when the panel is hidden.
+func (df *DetailsFrame) sidePanelWidth() int {
+	if !df.visible {
+		return 0
+	}
+	return df.width
+}
+
+func (df *DetailsFrame) resize(winWidth, winHeight int) {
+	width := winWidth / 3
+	if width < DetailsFrameMinWidth {
+		width = DetailsFrameMinWidth
+	}
+	if width > winWidth/2 {
+		width = winWidth / 2
+	}
+	df.width = width
+	df.x = winWidth - width
+	_, df.height = calcInfoFrameSize(winWidth, winHeight, width)
+}
+
+// update will draw details of the given item, only pods have details.
+func (df *DetailsFrame) update(s tcell.Screen, item Item) {
+	if !df.visible {
+		return
+	}
+	df.clear(s)
+
+	var lines []string
+	if pod, ok := item.(*Pod); ok {
+		lines = append([]string{pod.name, ""}, pod.details.lines()...)
+	} else {
+		lines = []string{"Move cursor to a pod to see its details."}
+	}
+
+	for index, line := range lines {
+		if index >= df.height {
+			break
+		}
+		drawS(s, line, df.x+DetailsItemXOffset, df.y+index, df.width-DetailsItemXOffset, tcell.StyleDefault)
+	}
+	for y := df.y; y < df.y+df.height; y++ {
+		s.SetContent(df.x, y, '|', nil, tcell.StyleDefault)
+	}
+}
+
+func (df *DetailsFrame) clear(s tcell.Screen) {
+	for y := df.y; y < df.y+df.height; y++ {
+		for x := df.x; x < df.x+df.width; x++ {
+			s.SetContent(x, y, ' ', nil, tcell.StyleDefault)
+		}
+	}
+}
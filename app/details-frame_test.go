@@ -0,0 +1,93 @@
+package app
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestPodDetailsLines(t *testing.T) {
+	seconds := int64(300)
+	p := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Labels:      map[string]string{"app": "web", "tier": "frontend"},
+			Annotations: map[string]string{"team": "platform"},
+		},
+		Spec: v1.PodSpec{
+			NodeName:           "node-1",
+			ServiceAccountName: "web",
+			Tolerations: []v1.Toleration{
+				{Key: "node.kubernetes.io/not-ready", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "web", Effect: v1.TaintEffectNoSchedule},
+			},
+		},
+		Status: v1.PodStatus{
+			PodIP:    "10.0.0.5",
+			HostIP:   "192.168.0.1",
+			QOSClass: v1.PodQOSBurstable,
+		},
+	}
+
+	details := toPodDetails(&p)
+	expected := []string{
+		"Node: node-1",
+		"Pod IP: 10.0.0.5",
+		"Host IP: 192.168.0.1",
+		"QoS class: Burstable",
+		"Priority class: -",
+		"Service account: web",
+		"",
+		"Conditions:",
+		"",
+		"Tolerations (2):",
+		"  node.kubernetes.io/not-ready:NoExecute op=Exists for 300s",
+		"  dedicated=web:NoSchedule",
+		"",
+		"Labels:",
+		"  app=web",
+		"  tier=frontend",
+		"",
+		"Annotations:",
+		"  team=platform",
+	}
+
+	lines := details.lines()
+	if len(lines) != len(expected) {
+		t.Fatalf("Invalid number of lines. Want: %v, Got: %v %q", len(expected), len(lines), lines)
+	}
+	for index, want := range expected {
+		if lines[index] != want {
+			t.Errorf("Invalid line %v. Want: %q, Got: %q", index, want, lines[index])
+		}
+	}
+}
+
+func TestDetailsFrameResize(t *testing.T) {
+	testTable := []struct {
+		name          string
+		winWidth      int
+		visible       bool
+		expectedWidth int
+		expectedInfo  int
+	}{
+		{name: "hidden", winWidth: 150, visible: false, expectedWidth: 50, expectedInfo: 150},
+		{name: "third_of_window", winWidth: 150, visible: true, expectedWidth: 50, expectedInfo: 100},
+		{name: "min_width", winWidth: 90, visible: true, expectedWidth: DetailsFrameMinWidth, expectedInfo: 50},
+		{name: "max_half_of_window", winWidth: 60, visible: true, expectedWidth: 30, expectedInfo: 30},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			df := NewDetailsFrame(tc.winWidth, 40)
+			df.visible = tc.visible
+			if df.width != tc.expectedWidth {
+				t.Errorf("Invalid details width. Want: %v, Got: %v", tc.expectedWidth, df.width)
+			}
+			infoWidth, _ := calcInfoFrameSize(tc.winWidth, 40, df.sidePanelWidth())
+			if infoWidth != tc.expectedInfo {
+				t.Errorf("Invalid info frame width. Want: %v, Got: %v", tc.expectedInfo, infoWidth)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"sort"
+	"time"
+)
+
+// maxEventsPerItem limits how many of the most recent events are displayed under a single tree item.
+const maxEventsPerItem = 10
+
+// Events is an expandable node under Namespace, holding warning events which could not be attached to any displayed
+// Pod or PodGroup. It is also displayed when events could not be listed, err holds the reason.
+type Events struct {
+	events     []Event
+	err        error
+	isExpanded bool
+	namespace  *Namespace
+}
+
+func (e *Events) Type() Type {
+	return TypeEvents
+}
+
+func (e *Events) Level() int {
+	return 1
+}
+
+func (e *Events) Expanded(b bool) {
+	e.isExpanded = b
+}
+
+func (e *Events) IsExpanded() bool {
+	return e.isExpanded
+}
+
+func (e *Events) DisplayName() string {
+	if e.err != nil {
+		return fmt.Sprintf("Events (n/a: %v)", e.err)
+	}
+	return fmt.Sprintf("Events (%d)", len(e.events))
+}
+
+// Event is a single warning event, events for the same involved object, reason and message are merged together.
+type Event struct {
+	reason     string
+	message    string
+	objectKind string
+	objectName string
+	count      int32
+	lastSeen   time.Time
+	level      int
+	namespace  *Namespace
+}
+
+func (e *Event) Type() Type {
+	return TypeEvent
+}
+
+func (e *Event) Level() int {
+	return e.level
+}
+
+func (e *Event) Expanded(b bool) {
+}
+
+func (e *Event) IsExpanded() bool {
+	return false
+}
+
+func (e *Event) DisplayName() string {
+	return fmt.Sprintf("%v (x%d, %v ago) %v/%v: %v", e.reason, e.count, translateTimestampSince(e.lastSeen), e.objectKind, e.objectName, e.message)
+}
+
+// toEvents deduplicates events by involved object, reason and message summing up their counts, result is sorted by
+// last seen time, most recent first.
+func toEvents(v1Events []v1.Event, parent *Namespace) []Event {
+	type eventKey struct {
+		kind, name, reason, message string
+	}
+	merged := make(map[eventKey]*Event)
+	order := make([]eventKey, 0)
+
+	for index := range v1Events {
+		ev := &v1Events[index]
+		key := eventKey{ev.InvolvedObject.Kind, ev.InvolvedObject.Name, ev.Reason, ev.Message}
+		lastSeen := eventLastSeen(ev)
+		count := ev.Count
+		if ev.Series != nil && ev.Series.Count > count {
+			count = ev.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+
+		e, ok := merged[key]
+		if !ok {
+			e = &Event{
+				reason:     ev.Reason,
+				message:    ev.Message,
+				objectKind: ev.InvolvedObject.Kind,
+				objectName: ev.InvolvedObject.Name,
+				namespace:  parent,
+			}
+			merged[key] = e
+			order = append(order, key)
+		}
+		e.count += count
+		if lastSeen.After(e.lastSeen) {
+			e.lastSeen = lastSeen
+		}
+	}
+
+	events := make([]Event, 0, len(merged))
+	for _, key := range order {
+		events = append(events, *merged[key])
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].lastSeen.After(events[j].lastSeen)
+	})
+	return events
+}
+
+func eventLastSeen(ev *v1.Event) time.Time {
+	switch {
+	case ev.Series != nil && !ev.Series.LastObservedTime.IsZero():
+		return ev.Series.LastObservedTime.Time
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.CreationTimestamp.Time
+	}
+}
+
+// attachEvents places events under displayed pods or pod groups matching the involved object, everything else is
+// kept under namespace Events node. Pod groups are matched by controllers of their pods, both direct ones, e.g.
+// ReplicaSet, and top level owners, e.g. Deployment. err is an error of listing events, it is shown on namespace Events
+// node.
+func attachEvents(ns *Namespace, events []Event, err error) {
+	pods := make(map[string]*Pod)
+	// groups are keyed by controllerKey.
+	groups := make(map[string]*PodGroup)
+	for gIndex := range ns.deployments {
+		for pIndex := range ns.deployments[gIndex].pods {
+			pod := &ns.deployments[gIndex].pods[pIndex]
+			pods[pod.name] = pod
+			if pod.controllerKey != "" {
+				groups[pod.controllerKey] = ns.deployments[gIndex]
+			}
+			if pod.owner.Name != "" {
+				groups[controllerKey(pod.owner.Kind, pod.owner.Name)] = ns.deployments[gIndex]
+			}
+		}
+	}
+
+	nsEvents := make([]Event, 0)
+	for _, e := range events {
+		if pod, ok := pods[e.objectName]; ok && e.objectKind == "Pod" {
+			e.level = 3
+			pod.events = appendEvent(pod.events, e)
+			continue
+		}
+		if group, ok := groups[controllerKey(e.objectKind, e.objectName)]; ok {
+			e.level = 2
+			group.events = appendEvent(group.events, e)
+			continue
+		}
+		e.level = 2
+		nsEvents = appendEvent(nsEvents, e)
+	}
+	ns.events = Events{events: nsEvents, err: err, namespace: ns}
+}
+
+func appendEvent(events []Event, e Event) []Event {
+	if len(events) >= maxEventsPerItem {
+		return events
+	}
+	return append(events, e)
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToEventsAndAttach(t *testing.T) {
+	now := time.Now()
+	v1Events := []v1.Event{
+		fakeEvent("Pod", "web-1", "BackOff", 3, now.Add(-time.Minute)),
+		fakeEvent("Pod", "web-1", "BackOff", 2, now),
+		fakeEvent("ReplicaSet", "web-5d8f7c9b4", "FailedCreate", 1, now.Add(-time.Hour)),
+		fakeEvent("Deployment", "web", "ProgressDeadlineExceeded", 1, now.Add(-2*time.Hour)),
+		fakeEvent("Pod", "gone-1", "FailedScheduling", 0, now.Add(-2*time.Minute)),
+		// Other kinds named like the group are not related to it.
+		fakeEvent("Service", "web", "SyncLoadBalancerFailed", 1, now.Add(-3*time.Minute)),
+	}
+
+	ns := Namespace{name: "ns", context: "context"}
+	ns.deployments = []*PodGroup{{name: "web", namespace: &ns, pods: []Pod{{
+		name:          "web-1",
+		controllerKey: controllerKey(KindReplicaSet, "web-5d8f7c9b4"),
+		owner:         podOwner{Kind: KindDeployment, Name: "web"},
+	}}}}
+
+	events := toEvents(v1Events, &ns)
+	if len(events) != 5 {
+		t.Fatalf("Invalid event count after deduplication. Want: %v, Got: %v", 5, len(events))
+	}
+	if events[0].count != 5 || !events[0].lastSeen.Equal(now) {
+		t.Errorf("Invalid merged event. Want count: %v, last seen: %v, Got: %+v", 5, now, events[0])
+	}
+
+	attachEvents(&ns, events, nil)
+	pod := &ns.deployments[0].pods[0]
+	if len(pod.events) != 1 || pod.events[0].reason != "BackOff" || pod.events[0].Level() != 3 {
+		t.Errorf("Pod event not attached, Got: %+v", pod.events)
+	}
+	groupEvents := ns.deployments[0].events
+	if len(groupEvents) != 2 || groupEvents[0].reason != "FailedCreate" || groupEvents[1].reason != "ProgressDeadlineExceeded" {
+		t.Errorf("ReplicaSet and Deployment events should be attached to the group, Got: %+v", groupEvents)
+	}
+	nsEvents := ns.events.events
+	if len(nsEvents) != 2 || nsEvents[0].objectName != "gone-1" || nsEvents[0].count != 1 || nsEvents[1].objectKind != "Service" {
+		t.Errorf("Unmatched events should stay under namespace, Got: %+v", nsEvents)
+	}
+}
+
+func TestEventsError(t *testing.T) {
+	plr := PodListResult{namespace: "ns", context: "context", eventsError: errors.New("events is forbidden")}
+	ns := toNamespace(&plr, defaultGroupingRules())
+
+	if expected := "Events (n/a: events is forbidden)"; ns.events.DisplayName() != expected {
+		t.Errorf("Invalid events name. Want: %v, Got: %v", expected, ns.events.DisplayName())
+	}
+	frame := InfoFrame{nsItems: []Namespace{ns}}
+	frame.nsItems[0].isExpanded = true
+	frame.updatePositions()
+	found := false
+	for _, item := range frame.positions {
+		found = found || item.Type() == TypeEvents
+	}
+	if !found {
+		t.Errorf("Events node should be listed when events could not be fetched")
+	}
+}
+
+func fakeEvent(kind, name, reason string, count int32, lastSeen time.Time) v1.Event {
+	return v1.Event{
+		InvolvedObject: v1.ObjectReference{Kind: kind, Name: name},
+		Reason:         reason,
+		Message:        reason + " message",
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+		Type:           v1.EventTypeWarning,
+	}
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"regexp"
+)
+
+// filter matches namespace, group, pod and container names against a case insensitive regex, patterns which are not
+// a valid regex are matched as a plain substring. Nil filter does not match anything.
+type filter struct {
+	pattern string
+	regex   *regexp.Regexp
+}
+
+func newFilter(pattern string) *filter {
+	if pattern == "" {
+		return nil
+	}
+	regex, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		regex = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+	}
+	return &filter{pattern: pattern, regex: regex}
+}
+
+func (fl *filter) matches(value string) bool {
+	return fl != nil && fl.regex.MatchString(value)
+}
+
+// find returns start and end of the first match in value, nil if there is none.
+func (fl *filter) find(value string) []int {
+	if fl == nil {
+		return nil
+	}
+	loc := fl.regex.FindStringIndex(value)
+	if loc == nil || loc[0] == loc[1] {
+		return nil
+	}
+	return loc
+}
+
+// namespaceHasMatch returns true when any group, pod or container of the namespace matches, healthy ones are skipped
+// when unhealthyOnly is set, as they are not displayed.
+func (fl *filter) namespaceHasMatch(ns *Namespace, unhealthyOnly bool) bool {
+	for dIndex := range ns.deployments {
+		if unhealthyOnly && ns.deployments[dIndex].isHealthy() {
+			continue
+		}
+		if fl.matches(ns.deployments[dIndex].name) || fl.podGroupHasMatch(ns.deployments[dIndex], unhealthyOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fl *filter) podGroupHasMatch(pg *PodGroup, unhealthyOnly bool) bool {
+	for pIndex := range pg.pods {
+		if unhealthyOnly && pg.pods[pIndex].isHealthy() {
+			continue
+		}
+		if fl.matches(pg.pods[pIndex].name) || fl.podHasMatch(&pg.pods[pIndex]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (fl *filter) podHasMatch(p *Pod) bool {
+	for cIndex := range p.containers {
+		if fl.matches(p.containers[cIndex].name) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTarget returns the name matched by filter for the item and its x position on screen, empty name is returned
+// for items which are not filtered.
+func filterTarget(item Item) (name string, x int) {
+	switch i := item.(type) {
+	case *Namespace:
+		return i.DisplayName(), NamespaceXOffset
+	case *PodGroup:
+		return i.name, PodGroupXOffset
+	case *Pod:
+		return i.name, PodXOffset
+	case *Container:
+		return i.name, ContainerXOffset + len(i.marker())
+	default:
+		return "", 0
+	}
+}
+
+// highlightMatch will redraw matching part of the item name in reverse, keeping colours already drawn.
+func (f *InfoFrame) highlightMatch(s tcell.Screen, item Item, yPos int) {
+	name, x := filterTarget(item)
+	loc := f.filter.find(name)
+	if loc == nil {
+		return
+	}
+	for i := loc[0]; i < loc[1] && x+i-f.xOffset < f.width; i++ {
+		if x+i < f.xOffset {
+			continue
+		}
+		mainc, combc, style, _ := s.GetContent(f.x+x+i-f.xOffset, f.y+yPos)
+		s.SetContent(f.x+x+i-f.xOffset, f.y+yPos, mainc, combc, style.Reverse(true))
+	}
+}
+
+// nextMatch returns position of the next (or previous when forward is false) matching item relative to current one,
+// search wraps around. Current position is returned when there are no matches.
+func (f *InfoFrame) nextMatch(current int, forward bool) int {
+	if len(f.matchPositions) == 0 {
+		return current
+	}
+	if forward {
+		for _, pos := range f.matchPositions {
+			if pos > current {
+				return pos
+			}
+		}
+		return f.matchPositions[0]
+	}
+	for index := len(f.matchPositions) - 1; index >= 0; index-- {
+		if f.matchPositions[index] < current {
+			return f.matchPositions[index]
+		}
+	}
+	return f.matchPositions[len(f.matchPositions)-1]
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewFilter(t *testing.T) {
+	testTable := []struct {
+		name     string
+		pattern  string
+		value    string
+		expected []int
+	}{
+		{name: "substring_case_insensitive", pattern: "WEB", value: "my-web-1", expected: []int{3, 6}},
+		{name: "regex", pattern: "web-[0-9]+$", value: "my-web-12", expected: []int{3, 9}},
+		{name: "invalid_regex_is_literal", pattern: "web(", value: "my-web(1)", expected: []int{3, 7}},
+		{name: "no_match", pattern: "api", value: "my-web-1", expected: nil},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := newFilter(tc.pattern).find(tc.value); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Invalid match. Want: %v, Got: %v", tc.expected, got)
+			}
+		})
+	}
+
+	if newFilter("") != nil {
+		t.Errorf("Empty pattern should not create a filter")
+	}
+}
+
+func TestFilterPositions(t *testing.T) {
+	frame := InfoFrame{}
+	frame.nsItems = []Namespace{
+		{name: "ns1", context: "context", deployments: []*PodGroup{
+			{name: "web", pods: []Pod{
+				{name: "web-1", containers: []Container{{name: "app"}, {name: "proxy"}}},
+				{name: "web-2", containers: []Container{{name: "app"}}},
+			}},
+			{name: "api", isExpanded: true, pods: []Pod{{name: "api-1"}}},
+		}},
+		{name: "ns2", context: "context", deployments: []*PodGroup{
+			{name: "db", pods: []Pod{{name: "db-0"}}},
+		}},
+	}
+	frame.filter = newFilter("proxy|api")
+
+	frame.updatePositions()
+
+	// Ancestors of proxy container are expanded, api group matches itself so it keeps its own expanded state.
+	expected := []string{"ns1 / context", "web", "web-1", "proxy", "api", "api-1"}
+	names := make([]string, 0, len(frame.positions))
+	for _, item := range frame.positions {
+		name, _ := filterTarget(item)
+		names = append(names, name)
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Invalid positions. Want: %v, Got: %v", expected, names)
+	}
+	if !reflect.DeepEqual(frame.matchPositions, []int{3, 4, 5}) {
+		t.Errorf("Invalid match positions. Want: %v, Got: %v", []int{3, 4, 5}, frame.matchPositions)
+	}
+
+	testTable := []struct {
+		current  int
+		forward  bool
+		expected int
+	}{
+		{current: 0, forward: true, expected: 3},
+		{current: 3, forward: true, expected: 4},
+		{current: 5, forward: true, expected: 3},
+		{current: 4, forward: false, expected: 3},
+		{current: 3, forward: false, expected: 5},
+	}
+	for _, tc := range testTable {
+		if got := frame.nextMatch(tc.current, tc.forward); got != tc.expected {
+			t.Errorf("Invalid next match from %v (forward %v). Want: %v, Got: %v", tc.current, tc.forward, tc.expected, got)
+		}
+	}
+}
+
+func TestNoMatchFilterItemInfo(t *testing.T) {
+	gui := Gui{mainFrame: &InfoFrame{nsItems: fakeNamespaces(5), filter: newFilter("zzz")}}
+	gui.mainFrame.updatePositions()
+
+	if len(gui.mainFrame.positions) != 0 {
+		t.Fatalf("Filter should not match anything, got %v positions", len(gui.mainFrame.positions))
+	}
+	if _, ok := gui.getCurrentGuiItemInfo(); ok {
+		t.Errorf("Item info should not be available without positions")
+	}
+}
+
+func TestFilterWithUnhealthyOnly(t *testing.T) {
+	healthy := Pod{name: "web-1", status: "Running", ready: 1, total: 1}
+	failing := Pod{name: "web-2", status: "CrashLoopBackOff", ready: 0, total: 1}
+	frame := InfoFrame{unhealthyOnly: true, filter: newFilter("web-1|db")}
+	frame.nsItems = []Namespace{
+		// Only match is a healthy pod in an unhealthy group.
+		{name: "ns1", context: "context", isExpanded: true, deployments: []*PodGroup{
+			{name: "web", isExpanded: true, pods: []Pod{healthy, failing}},
+		}},
+		// Healthy namespace is displayed as a summary line, matching group is not displayed.
+		{name: "ns2", context: "context", isExpanded: true, deployments: []*PodGroup{
+			{name: "db", isExpanded: true, pods: []Pod{{name: "db-0", status: "Running", ready: 1, total: 1}}},
+		}},
+	}
+
+	frame.updatePositions()
+
+	if len(frame.positions) != 0 {
+		names := make([]string, 0, len(frame.positions))
+		for _, item := range frame.positions {
+			name, _ := filterTarget(item)
+			names = append(names, name)
+		}
+		t.Errorf("Namespaces without displayed matches should be hidden, got: %v", names)
+	}
+}
+
+func TestTrimLastRune(t *testing.T) {
+	testTable := []struct {
+		value    string
+		expected string
+	}{
+		{value: "web", expected: "we"},
+		{value: "café", expected: "caf"},
+		{value: "x日本", expected: "x日"},
+		{value: "", expected: ""},
+	}
+	for _, tc := range testTable {
+		if got := trimLastRune(tc.value); got != tc.expected {
+			t.Errorf("Invalid value for %q. Want: %q, Got: %q", tc.value, tc.expected, got)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 )
 
+var footerDivider = strings.Repeat("-", 25)
+
 type FooterFrame struct {
 	x, y               int
 	width, height      int
@@ -27,7 +29,7 @@ func NewFooterFrame(s tcell.Screen) *FooterFrame {
 		statusBar:          &StringItem{x: 0, y: winHeight - 1, length: 0, value: ""},
 		statusBarCh:        sbCh,
 	}
-	frame.lines[0] = strings.Repeat("-", 25)
+	frame.lines[0] = footerDivider
 	frame.listenForStatusMessages(s)
 	return &frame
 }
@@ -59,9 +61,19 @@ func (ff *FooterFrame) updateShortcutInfo(s tcell.Screen, i Item) {
 	ff.update(s)
 }
 
+// updateSearchLine shows search input or active filter in place of the divider line, empty value restores the divider.
+func (ff *FooterFrame) updateSearchLine(s tcell.Screen, value string) {
+	if value == "" {
+		ff.lines[0] = footerDivider
+	} else {
+		ff.lines[0] = value
+	}
+	ff.update(s)
+}
+
 func (ff *FooterFrame) update(s tcell.Screen) {
 	for k, v := range ff.lines {
-		drawS(s, v, 0, ff.y+k, ff.width, tcell.StyleDefault)
+		drawS(s, v, 0, ff.y+k, ff.width, activeTheme.style(StateFooter))
 	}
 }
 
@@ -0,0 +1,157 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"regexp"
+	"strings"
+)
+
+const (
+	GroupingOwner      = "owner"
+	GroupingLabel      = "label"
+	GroupingAnnotation = "annotation"
+	GroupingRegex      = "regex"
+	// DefaultGroupName is used for pods not matching any grouping rule.
+	DefaultGroupName = "_"
+)
+
+// GroupingRule is a single pod grouping strategy, as defined in config.yaml 'grouping' section or in groups.json.
+// Rules are applied in order, first one returning non empty name is used as a group name.
+type GroupingRule struct {
+	// Type is one of owner, label, annotation or regex.
+	Type string `json:"type"`
+	// Key is a label or annotation key.
+	Key string `json:"key,omitempty"`
+	// Pattern is a regex applied on pod name, first capture group is used as a group name.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+type groupingRule struct {
+	ruleType string
+	key      string
+	regex    *regexp.Regexp
+}
+
+// defaultGroupingRules returns rules matching original behaviour: top level controller name, then labels deployment,
+// statefulSet, job-name and app.
+func defaultGroupingRules() []groupingRule {
+	return []groupingRule{
+		{ruleType: GroupingOwner},
+		{ruleType: GroupingLabel, key: "deployment"},
+		{ruleType: GroupingLabel, key: "statefulSet"},
+		{ruleType: GroupingLabel, key: "job-name"},
+		{ruleType: GroupingLabel, key: "app"},
+	}
+}
+
+func compileGroupingRules(rules []GroupingRule) ([]groupingRule, error) {
+	compiled := make([]groupingRule, 0, len(rules))
+	for index, rule := range rules {
+		gr := groupingRule{ruleType: strings.ToLower(rule.Type), key: rule.Key}
+		switch gr.ruleType {
+		case GroupingOwner:
+		case GroupingLabel, GroupingAnnotation:
+			if rule.Key == "" {
+				return nil, errors.New(fmt.Sprintf("key not provided for grouping rule %v '%v'", index, rule.Type))
+			}
+		case GroupingRegex:
+			regex, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("invalid pattern in grouping rule %v: %v", index, err))
+			}
+			if regex.NumSubexp() < 1 {
+				return nil, errors.New(fmt.Sprintf("pattern '%v' in grouping rule %v has no capture group", rule.Pattern, index))
+			}
+			gr.regex = regex
+		default:
+			return nil, errors.New(fmt.Sprintf("unknown grouping rule type '%v'", rule.Type))
+		}
+		compiled = append(compiled, gr)
+	}
+	return compiled, nil
+}
+
+// convertGroupingFromViperSettings reads 'grouping' list from config, nil is returned if it is not present.
+func convertGroupingFromViperSettings(settings map[string]interface{}) ([]GroupingRule, error) {
+	value, ok := settings["grouping"]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("grouping should be a list of rules")
+	}
+
+	rules := make([]GroupingRule, 0, len(list))
+	for index, item := range list {
+		m, ok := toStringMap(item)
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("invalid grouping rule %v", index))
+		}
+		rule := GroupingRule{}
+		rule.Type, _ = m["type"].(string)
+		rule.Key, _ = m["key"].(string)
+		rule.Pattern, _ = m["pattern"].(string)
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// toStringMap converts nested yaml maps, which can come from viper with interface{} keys.
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			result[strings.ToLower(fmt.Sprint(k))] = v
+		}
+		return result, true
+	default:
+		return nil, false
+	}
+}
+
+// getGroupingRules returns rules from the group definition if present, otherwise from config, otherwise defaults.
+func getGroupingRules(group Group, settings map[string]interface{}) ([]groupingRule, error) {
+	if len(group.Grouping) > 0 {
+		return compileGroupingRules(group.Grouping)
+	}
+	rules, err := convertGroupingFromViperSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		return defaultGroupingRules(), nil
+	}
+	return compileGroupingRules(rules)
+}
+
+func (gr groupingRule) groupName(pod *v1.Pod, owner podOwner) string {
+	switch gr.ruleType {
+	case GroupingOwner:
+		return owner.Name
+	case GroupingLabel:
+		return pod.Labels[gr.key]
+	case GroupingAnnotation:
+		return pod.Annotations[gr.key]
+	case GroupingRegex:
+		match := gr.regex.FindStringSubmatch(pod.Name)
+		if len(match) > 1 {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+func podGroupName(pod *v1.Pod, owner podOwner, rules []groupingRule) string {
+	for _, rule := range rules {
+		if name := rule.groupName(pod, owner); name != "" {
+			return name
+		}
+	}
+	return DefaultGroupName
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestCompileGroupingRules(t *testing.T) {
+	testTable := []struct {
+		name    string
+		rules   []GroupingRule
+		wantErr bool
+	}{
+		{"owner", []GroupingRule{{Type: "owner"}}, false},
+		{"label", []GroupingRule{{Type: "Label", Key: "app"}}, false},
+		{"label without key", []GroupingRule{{Type: "label"}}, true},
+		{"annotation without key", []GroupingRule{{Type: "annotation"}}, true},
+		{"regex", []GroupingRule{{Type: "regex", Pattern: "^(.+)-[0-9]+$"}}, false},
+		{"regex without capture group", []GroupingRule{{Type: "regex", Pattern: "^.+$"}}, true},
+		{"invalid regex", []GroupingRule{{Type: "regex", Pattern: "(["}}, true},
+		{"unknown type", []GroupingRule{{Type: "foo"}}, true},
+	}
+
+	for _, tc := range testTable {
+		_, err := compileGroupingRules(tc.rules)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%v: unexpected error result. Want error: %v, Got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
+
+func TestPodGroupName(t *testing.T) {
+	isController := true
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "web-0",
+		Labels:      map[string]string{"app": "web-app", "app.kubernetes.io/instance": "web-release", "pod-template-hash": "7d4b9c"},
+		Annotations: map[string]string{"team": "platform"},
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: KindReplicaSet, Name: "web-7d4b9c", Controller: &isController},
+		},
+	}}
+
+	testTable := []struct {
+		name  string
+		rules []GroupingRule
+		want  string
+	}{
+		{"owner", []GroupingRule{{Type: GroupingOwner}}, "web"},
+		{"label", []GroupingRule{{Type: GroupingLabel, Key: "app.kubernetes.io/instance"}}, "web-release"},
+		{"annotation", []GroupingRule{{Type: GroupingAnnotation, Key: "team"}}, "platform"},
+		{"regex", []GroupingRule{{Type: GroupingRegex, Pattern: "^(.+)-[0-9]+$"}}, "web"},
+		{"first match wins", []GroupingRule{{Type: GroupingLabel, Key: "missing"}, {Type: GroupingLabel, Key: "app"}, {Type: GroupingOwner}}, "web-app"},
+		{"no match", []GroupingRule{{Type: GroupingLabel, Key: "missing"}, {Type: GroupingRegex, Pattern: "^db-(.+)$"}}, DefaultGroupName},
+		{"no rules", []GroupingRule{}, DefaultGroupName},
+	}
+
+	for _, tc := range testTable {
+		rules, err := compileGroupingRules(tc.rules)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.name, err)
+		}
+		if got := podGroupName(&pod, fallbackOwner(&pod), rules); got != tc.want {
+			t.Errorf("%v: invalid group name. Want: %v, Got: %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestGetGroupingRules(t *testing.T) {
+	settings := map[string]interface{}{
+		"grouping": []interface{}{
+			map[interface{}]interface{}{"type": "label", "key": "app.kubernetes.io/name"},
+		},
+	}
+
+	rules, err := getGroupingRules(Group{}, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 1 || rules[0].key != "app.kubernetes.io/name" {
+		t.Errorf("rules from config not used, got: %+v", rules)
+	}
+
+	group := Group{Grouping: []GroupingRule{{Type: GroupingOwner}, {Type: GroupingLabel, Key: "app"}}}
+	rules, err = getGroupingRules(group, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != 2 || rules[0].ruleType != GroupingOwner {
+		t.Errorf("group rules should override config, got: %+v", rules)
+	}
+
+	rules, err = getGroupingRules(Group{}, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules) != len(defaultGroupingRules()) {
+		t.Errorf("default rules expected when not configured, got: %+v", rules)
+	}
+}
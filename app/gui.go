@@ -4,58 +4,83 @@ import (
 	"fmt"
 	"github.com/JLevconoks/k8ConsoleViewer/terminal"
 	"github.com/gdamore/tcell/v2"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 const (
-	NamespaceXOffset           = 0
-	NamespaceErrorXOffset      = 2
-	NamespaceMessageXOffset    = 2
-	PodGroupXOffset            = 1
-	PodXOffset                 = 2
-	ContainerXOffset           = 4
-	ColumnSpacing              = 2
-	NameColumnDefaultWidth     = 25 + ColumnSpacing
-	ReadyColumnDefaultWidth    = 5 + ColumnSpacing
-	StatusColumnDefaultWidth   = 6 + ColumnSpacing
-	RestartsColumnDefaultWidth = 8 + ColumnSpacing
-	AgeColumnDefaultWidth      = 3 + ColumnSpacing
-	MainFrameStartY            = 4 //Excluding header line.
-	FooterFrameHeight          = 4 //Including divider line.
+	NamespaceXOffset        = 0
+	NamespaceErrorXOffset   = 2
+	NamespaceMessageXOffset = 2
+	PodGroupXOffset         = 1
+	PodXOffset              = 2
+	ContainerXOffset        = 4
+	EventsXOffset           = 2
+	ContainerDetailXOffset  = 6
+	ColumnSpacing           = 2
+	NameColumnDefaultWidth  = 25 + ColumnSpacing
+	ReadyColumnDefaultWidth = 5 + ColumnSpacing
+	MainFrameStartY         = 4 //Excluding header line.
+	DefaultRefreshInterval  = 5 * time.Second
+	FooterFrameHeight       = 4 //Including divider line.
 )
 
 type Gui struct {
-	s           tcell.Screen
-	currentTime StringItem
-	execLabel   StringItem
-	execTime    StringItem
-	groupName   StringItem
-	mainFrame   *InfoFrame
-	footerFrame *FooterFrame
-	popupFrame  *PopupFrame
+	s            tcell.Screen
+	currentTime  StringItem
+	execLabel    StringItem
+	execTime     StringItem
+	groupName    StringItem
+	watchStatus  StringItem
+	mainFrame    *InfoFrame
+	detailsFrame *DetailsFrame
+	footerFrame  *FooterFrame
+	popupFrame   *PopupFrame
+	logFrame     *LogFrame
+	// logStreamer is used by logFrame, it is nil when logs are not available, e.g. in replay.
+	logStreamer   logStreamer
+	describeFrame *DescribeFrame
+	// describer is used by describeFrame, it is nil when cluster is not available, e.g. in replay.
+	describer objectDescriber
+	// terminal opens windows for exec and, with LogViewerTerminal, for logs.
+	terminal    terminal.Backend
 	statusBarCh chan string
+	// searchInput is true while search text is being typed in the footer, all keys go to handleSearchKey.
+	searchInput bool
+	searchText  string
 }
 
-func NewGui(s tcell.Screen, name string) Gui {
+func NewGui(s tcell.Screen, name, selector string) Gui {
 	sw, sh := s.Size()
 
 	currentTime := StringItem{0, 0, 30, time.Now().Format(time.RFC1123Z)}
 	execLabel := StringItem{currentTime.length + 3, 0, 17, "Time to execute: "}
 	execTime := StringItem{execLabel.x + execLabel.length, 0, 0, "0ms"}
-	groupName := StringItem{0, 1, 0, fmt.Sprintf("Group: %v", name)}
+	groupLabel := fmt.Sprintf("Group: %v", name)
+	if selector != "" {
+		groupLabel += fmt.Sprintf("   Selector: %v", selector)
+	}
+	groupName := StringItem{0, 1, 0, groupLabel}
+	watchStatus := StringItem{0, 2, 0, ""}
 
 	footerFrame := NewFooterFrame(s)
 
 	return Gui{
-		s:           s,
-		currentTime: currentTime,
-		execLabel:   execLabel,
-		execTime:    execTime,
-		groupName:   groupName,
-		mainFrame:   NewInfoFrame(sw, sh),
-		footerFrame: footerFrame,
-		popupFrame:  NewPopupFrame(s, "", nil, nil),
-		statusBarCh: footerFrame.statusBarCh,
+		s:             s,
+		currentTime:   currentTime,
+		execLabel:     execLabel,
+		execTime:      execTime,
+		groupName:     groupName,
+		watchStatus:   watchStatus,
+		mainFrame:     NewInfoFrame(sw, sh),
+		detailsFrame:  NewDetailsFrame(sw, sh),
+		footerFrame:   footerFrame,
+		popupFrame:    NewPopupFrame(s, "", nil, nil),
+		logFrame:      NewLogFrame(sw, sh),
+		describeFrame: NewDescribeFrame(sw, sh),
+		statusBarCh:   footerFrame.statusBarCh,
 	}
 }
 
@@ -64,7 +89,7 @@ func (gui *Gui) show(s tcell.Screen) {
 	gui.execLabel.Draw(s)
 	gui.execTime.Draw(s)
 	gui.groupName.Draw(s)
-	gui.mainFrame.podHeader.Draw(s)
+	gui.mainFrame.podHeader.DrawS(s, activeTheme.style(StateHeader))
 	s.Show()
 }
 
@@ -74,16 +99,48 @@ func (gui *Gui) updateNamespaces(s tcell.Screen, podListResults []PodListResult,
 	// TODO Might be worth moving timeToExec into separate struct and move this logic into a method.
 	timeStyle := tcell.StyleDefault
 	if timeToExec > time.Duration(1)*time.Second {
-		timeStyle = timeStyle.Foreground(tcell.ColorYellow)
+		timeStyle = activeTheme.style(StateWarning)
 	}
 	gui.execTime.UpdateS(s, timeToExec.String(), timeStyle)
 	gui.currentTime.Update(s, time.Now().Format(time.RFC1123Z))
+	gui.updateWatchStatus(s)
 	gui.redraw(s)
 	gui.mainFrame.Mutex.Unlock()
 	gui.statusBarCh <- ""
 }
 
+// updateReplayInfo shows recorded time instead of current time and replay position next to group name.
+func (gui *Gui) updateReplayInfo(s tcell.Screen, recordedTime time.Time, label string) {
+	gui.currentTime.Update(s, recordedTime.Format(time.RFC1123Z))
+	gui.groupName.Update(s, label)
+	s.Show()
+}
+
+func (gui *Gui) updateWatchStatus(s tcell.Screen) {
+	statuses := make([]string, 0)
+	for nsIndex := range gui.mainFrame.nsItems {
+		ns := &gui.mainFrame.nsItems[nsIndex]
+		switch {
+		case !ns.nextRetry.IsZero():
+			statuses = append(statuses, fmt.Sprintf("%v retry at %v", ns.DisplayName(), ns.nextRetry.Format("15:04:05")))
+		case ns.reconnecting:
+			statuses = append(statuses, fmt.Sprintf("%v reconnecting", ns.DisplayName()))
+		}
+	}
+
+	if len(statuses) == 0 {
+		gui.watchStatus.Update(s, "")
+		return
+	}
+	gui.watchStatus.UpdateS(s, strings.Join(statuses, ", "), activeTheme.style(StateWarning))
+}
+
 func (gui *Gui) redraw(s tcell.Screen) {
+	if overlay := gui.overlay(); overlay != nil {
+		overlay.redraw(s)
+		s.Show()
+		return
+	}
 	gui.mainFrame.refresh(s)
 	gui.updateStatusFrame()
 	if gui.popupFrame != nil && gui.popupFrame.visible {
@@ -125,13 +182,211 @@ func (gui *Gui) handleKeyRight() {
 	gui.s.Show()
 }
 
+// toggleMark marks or unmarks the pod under cursor.
+func (gui *Gui) toggleMark() {
+	if gui.mainFrame.toggleMark(gui.s) {
+		gui.updateStatusFrame()
+		gui.s.Show()
+	}
+}
+
+// toggleMarkAll marks or unmarks all pods of the namespace or pod group under cursor.
+func (gui *Gui) toggleMarkAll() {
+	if gui.mainFrame.toggleMarkAll(gui.s) {
+		gui.updateStatusFrame()
+		gui.s.Show()
+	}
+}
+
+// clearMarks returns false when there were no marked pods.
+func (gui *Gui) clearMarks() bool {
+	if !gui.mainFrame.clearMarks(gui.s) {
+		return false
+	}
+	gui.updateStatusFrame()
+	gui.s.Show()
+	return true
+}
+
+// scrollColumns scrolls the main frame horizontally by n characters.
+func (gui *Gui) scrollColumns(n int) {
+	gui.mainFrame.scrollColumns(gui.s, n)
+	gui.updateStatusFrame()
+	gui.s.Show()
+}
+
 func (gui *Gui) handleResize() {
 	winWidth, winHeight := gui.s.Size()
-	gui.mainFrame.resize(gui.s, winWidth, winHeight)
+	gui.detailsFrame.resize(winWidth, winHeight)
+	gui.mainFrame.resize(gui.s, winWidth, winHeight, gui.detailsFrame.sidePanelWidth())
 	gui.footerFrame.resize(gui.s, winWidth, winHeight)
+	gui.logFrame.resizeFrame(winWidth, winHeight)
+	gui.describeFrame.resizeFrame(winWidth, winHeight)
+	if overlay := gui.overlay(); overlay != nil {
+		overlay.redraw(gui.s)
+	} else {
+		gui.updateStatusFrame()
+	}
+	gui.s.Show()
+}
+
+// showLogs opens LogFrame with logs of marked pods or, when none are marked, of the pod group, pod or container under
+// the cursor.
+func (gui *Gui) showLogs(follow bool) {
+	if len(gui.mainFrame.positions) == 0 {
+		return
+	}
+	if gui.logStreamer == nil {
+		gui.statusBarCh <- "Logs are not available."
+		return
+	}
+	var title string
+	var targets []logTarget
+	if marked := gui.mainFrame.markedPods(); len(marked) > 0 {
+		title, targets = markedLogTargets(marked)
+	} else {
+		title, targets = logTargets(gui.mainFrame.positions[gui.mainFrame.cursorFullPosition()])
+	}
+	if len(targets) == 0 {
+		gui.statusBarCh <- "Logs are available for pod groups, pods and containers."
+		return
+	}
+	gui.logFrame.open(gui.s, gui.logStreamer, title, targets, follow)
+}
+
+// showDescribe opens DescribeFrame for the namespace, pod group owner or pod under the cursor.
+func (gui *Gui) showDescribe() {
+	if len(gui.mainFrame.positions) == 0 {
+		return
+	}
+	if gui.describer == nil {
+		gui.statusBarCh <- "Describe is not available."
+		return
+	}
+	ref, err := describeTarget(gui.mainFrame.positions[gui.mainFrame.cursorFullPosition()])
+	if err != nil {
+		gui.statusBarCh <- "Error: " + err.Error()
+		return
+	}
+	gui.describeFrame.open(gui.s, gui.describer, ref)
+}
+
+// overlayFrame is a full screen frame drawn instead of the main frame while it is visible, it handles all keys.
+type overlayFrame interface {
+	redraw(s tcell.Screen)
+	// handleKey returns false when the frame was closed.
+	handleKey(s tcell.Screen, ev *tcell.EventKey) bool
+	scrollView(s tcell.Screen, n int)
+}
+
+// overlay returns visible overlay frame, nil when main frame is displayed.
+func (gui *Gui) overlay() overlayFrame {
+	switch {
+	case gui.logFrame.visible:
+		return gui.logFrame
+	case gui.describeFrame.visible:
+		return gui.describeFrame
+	default:
+		return nil
+	}
+}
+
+// handleOverlayKey passes keys to visible overlay frame, whole screen is redrawn once the frame is closed.
+func (gui *Gui) handleOverlayKey(ev *tcell.EventKey) {
+	if gui.overlay().handleKey(gui.s, ev) {
+		return
+	}
+	gui.s.Clear()
+	gui.show(gui.s)
+	gui.watchStatus.Draw(gui.s)
+	gui.footerFrame.update(gui.s)
+	gui.redraw(gui.s)
+}
+
+func (gui *Gui) startSearch() {
+	gui.searchInput = true
+	gui.searchText = ""
+	gui.applySearch()
+}
+
+// handleSearchKey edits search text, filter is applied on every change. Enter keeps the filter, Esc clears it.
+func (gui *Gui) handleSearchKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		gui.searchInput = false
+	case tcell.KeyEscape:
+		gui.searchInput = false
+		gui.searchText = ""
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(gui.searchText) > 0 {
+			gui.searchText = trimLastRune(gui.searchText)
+		}
+	case tcell.KeyRune:
+		gui.searchText += string(ev.Rune())
+	default:
+		return
+	}
+	gui.applySearch()
+}
+
+// trimLastRune removes the last character, which can be longer than a byte.
+func trimLastRune(value string) string {
+	_, size := utf8.DecodeLastRuneInString(value)
+	return value[:len(value)-size]
+}
+
+func (gui *Gui) clearSearch() {
+	gui.searchInput = false
+	gui.searchText = ""
+	gui.applySearch()
+}
+
+// applySearch updates InfoFrame filter from search text and moves cursor to the first match.
+func (gui *Gui) applySearch() {
+	gui.mainFrame.filter = newFilter(gui.searchText)
+	gui.mainFrame.refresh(gui.s)
+	if len(gui.mainFrame.matchPositions) > 0 {
+		gui.mainFrame.moveCursor(gui.s, gui.mainFrame.matchPositions[0]-gui.mainFrame.cursorFullPosition())
+	}
+
+	switch {
+	case gui.searchInput:
+		gui.footerFrame.updateSearchLine(gui.s, "/"+gui.searchText)
+	case gui.mainFrame.filter != nil:
+		gui.footerFrame.updateSearchLine(gui.s, fmt.Sprintf("Filter: %v  (n/N next/previous match, Esc clear)", gui.searchText))
+	default:
+		gui.footerFrame.updateSearchLine(gui.s, "")
+	}
+	gui.redraw(gui.s)
+}
+
+func (gui *Gui) jumpToMatch(forward bool) {
+	current := gui.mainFrame.cursorFullPosition()
+	gui.mainFrame.moveCursor(gui.s, gui.mainFrame.nextMatch(current, forward)-current)
+	gui.updateStatusFrame()
 	gui.s.Show()
 }
 
+// toggleUnhealthyOnly switches between showing all items and only unhealthy ones.
+func (gui *Gui) toggleUnhealthyOnly() {
+	gui.mainFrame.unhealthyOnly = !gui.mainFrame.unhealthyOnly
+	gui.redraw(gui.s)
+}
+
+// cycleSortMode switches pod group and pod ordering to the next sort mode.
+func (gui *Gui) cycleSortMode() {
+	gui.mainFrame.cycleSortMode()
+	gui.redraw(gui.s)
+}
+
+// toggleDetailsFrame shows or hides pod details panel, InfoFrame is resized to make space for it.
+func (gui *Gui) toggleDetailsFrame() {
+	gui.detailsFrame.visible = !gui.detailsFrame.visible
+	winWidth, winHeight := gui.s.Size()
+	gui.mainFrame.resize(gui.s, winWidth, winHeight, gui.detailsFrame.sidePanelWidth())
+	gui.redraw(gui.s)
+}
+
 func (gui *Gui) handleCollapseEvent() {
 	gui.mainFrame.collapseByOneLevel(gui.s)
 	gui.updateStatusFrame()
@@ -195,7 +450,12 @@ func (gui *Gui) getLogsAndFollowFromPods() {
 	gui.handleCommandExec(cmdTemplate)
 }
 
-func (gui *Gui) getCurrentGuiItemInfo() GuiItemInfo {
+// getCurrentGuiItemInfo returns info of the item under the cursor, false when there are no items, e.g. search does not
+// match anything.
+func (gui *Gui) getCurrentGuiItemInfo() (GuiItemInfo, bool) {
+	if len(gui.mainFrame.positions) == 0 {
+		return GuiItemInfo{}, false
+	}
 	position := gui.mainFrame.cursorFullPosition()
 	item := gui.mainFrame.positions[position]
 	itemType := item.Type()
@@ -212,21 +472,56 @@ func (gui *Gui) getCurrentGuiItemInfo() GuiItemInfo {
 		data.Context = pg.namespace.context
 		data.Namespace = pg.namespace.name
 		data.Group = pg.name
+		data.Kind = strings.ToLower(pg.ownerKind)
 	case TypePod:
-		pod := item.(*Pod)
-		data.Context = pod.podGroup.namespace.context
-		data.Namespace = pod.podGroup.namespace.name
-		data.Group = pod.podGroup.name
-		data.Pod = pod.name
+		data = podItemInfo(item.(*Pod))
 	case TypeContainer:
 		c := item.(*Container)
 		data.Context = c.pod.podGroup.namespace.context
 		data.Namespace = c.pod.podGroup.namespace.name
 		data.Group = c.pod.podGroup.name
+		data.Kind = strings.ToLower(c.pod.podGroup.ownerKind)
+		data.Pod = c.pod.name
+		data.Container = c.name
+	case TypeContainerDetail:
+		c := item.(*ContainerDetail).container
+		data.Context = c.pod.podGroup.namespace.context
+		data.Namespace = c.pod.podGroup.namespace.name
+		data.Group = c.pod.podGroup.name
+		data.Kind = strings.ToLower(c.pod.podGroup.ownerKind)
 		data.Pod = c.pod.name
 		data.Container = c.name
+	case TypeEvents:
+		e := item.(*Events)
+		data.Context = e.namespace.context
+		data.Namespace = e.namespace.name
+	case TypeEvent:
+		e := item.(*Event)
+		data.Context = e.namespace.context
+		data.Namespace = e.namespace.name
+	}
+	return data, true
+}
+
+func podItemInfo(pod *Pod) GuiItemInfo {
+	return GuiItemInfo{
+		itemType:  TypePod,
+		Context:   pod.podGroup.namespace.context,
+		Namespace: pod.podGroup.namespace.name,
+		Group:     pod.podGroup.name,
+		Kind:      strings.ToLower(pod.podGroup.ownerKind),
+		Pod:       pod.name,
+	}
+}
+
+// markedItemInfos returns info of every marked pod, empty when no pods are marked.
+func (gui *Gui) markedItemInfos() []GuiItemInfo {
+	marked := gui.mainFrame.markedPods()
+	infos := make([]GuiItemInfo, 0, len(marked))
+	for _, pod := range marked {
+		infos = append(infos, podItemInfo(pod))
 	}
-	return data
+	return infos
 }
 
 func (gui *Gui) updateStatusFrame() {
@@ -235,7 +530,13 @@ func (gui *Gui) updateStatusFrame() {
 		return
 	}
 	item := gui.mainFrame.positions[gui.mainFrame.cursorFullPosition()]
-	gui.footerFrame.updateShortcutInfo(gui.s, item)
+	// Clipboard shortcuts apply to marked pods while there are any, so pod shortcuts are shown.
+	if marked := gui.mainFrame.markedPods(); len(marked) > 0 {
+		gui.footerFrame.updateShortcutInfo(gui.s, marked[0])
+	} else {
+		gui.footerFrame.updateShortcutInfo(gui.s, item)
+	}
+	gui.detailsFrame.update(gui.s, item)
 }
 
 func (gui *Gui) handleCommandExec(tmpl string) {
@@ -244,18 +545,22 @@ func (gui *Gui) handleCommandExec(tmpl string) {
 		return
 	}
 
-	position := gui.mainFrame.cursorFullPosition()
-	item := gui.mainFrame.positions[position]
-
-	context, nsName, podNames, contNames := gatherContainerInfos(item)
-
-	popupCallback := func(selected string) {
-		commands := assembleCommands(tmpl, context, nsName, selected, podNames)
-		if len(commands) > 0 {
-			err := terminal.OpenAndExecute(commands)
-			if err != nil {
-				gui.statusBarCh <- err.Error()
-			}
+	var popupCallback func(selected string)
+	var contNames []string
+	if marked := gui.mainFrame.markedPods(); len(marked) > 0 {
+		contNames = containerNamesFromPods(marked)
+		popupCallback = func(selected string) {
+			gui.executeCommands(assembleMarkedCommands(tmpl, selected, marked))
+		}
+	} else {
+		position := gui.mainFrame.cursorFullPosition()
+		item := gui.mainFrame.positions[position]
+
+		var context, nsName string
+		var podNames []string
+		context, nsName, podNames, contNames = gatherContainerInfos(item)
+		popupCallback = func(selected string) {
+			gui.executeCommands(assembleCommands(tmpl, context, nsName, selected, podNames))
 		}
 	}
 	gui.popupFrame = NewPopupFrame(gui.s, "Container", contNames, popupCallback)
@@ -264,6 +569,15 @@ func (gui *Gui) handleCommandExec(tmpl string) {
 	gui.s.Show()
 }
 
+func (gui *Gui) executeCommands(commands []string) {
+	if len(commands) > 0 {
+		err := gui.terminal.OpenAndExecute(commands)
+		if err != nil {
+			gui.statusBarCh <- err.Error()
+		}
+	}
+}
+
 func gatherContainerInfos(item Item) (context, nsName string, podNames, contNames []string) {
 	switch item.Type() {
 	case TypePodGroup:
@@ -284,6 +598,12 @@ func gatherContainerInfos(item Item) (context, nsName string, podNames, contName
 		nsName = c.pod.podGroup.namespace.name
 		podNames = c.pod.podGroup.podNames()
 		contNames = c.pod.containerNames()
+	case TypeContainerDetail:
+		c := item.(*ContainerDetail).container
+		context = c.pod.podGroup.namespace.context
+		nsName = c.pod.podGroup.namespace.name
+		podNames = c.pod.podGroup.podNames()
+		contNames = c.pod.containerNames()
 	}
 
 	return context, nsName, podNames, contNames
@@ -300,6 +620,22 @@ func assembleCommands(tmpl, context, nsName, contName string, pods []string) []s
 	return commands
 }
 
+// assembleMarkedCommands returns a command for every marked pod which has the container, pods can be from different
+// namespaces and contexts.
+func assembleMarkedCommands(tmpl, contName string, pods []*Pod) []string {
+	commands := make([]string, 0)
+
+	for _, p := range pods {
+		if !p.hasContainer(contName) {
+			continue
+		}
+		ns := p.podGroup.namespace
+		commands = append(commands, fmt.Sprintf(tmpl, ns.context, ns.name, p.name, contName))
+	}
+
+	return commands
+}
+
 func drawS(s tcell.Screen, value string, x, y, length int, style tcell.Style) {
 	for i := 0; i < length; i++ {
 		r := ' '
@@ -332,3 +668,20 @@ func containerNamesFromPodGroup(pg *PodGroup) []string {
 
 	return contNames
 }
+
+// containerNamesFromPods returns sorted distinct container names of all pods.
+func containerNamesFromPods(pods []*Pod) []string {
+	nameSet := make(map[string]struct{})
+	for _, p := range pods {
+		for _, name := range p.containerNames() {
+			nameSet[name] = struct{}{}
+		}
+	}
+
+	contNames := make([]string, 0, len(nameSet))
+	for name := range nameSet {
+		contNames = append(contNames, name)
+	}
+	sort.Strings(contNames)
+	return contNames
+}
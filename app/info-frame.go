@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/gdamore/tcell/v2"
 	"sort"
-	"strconv"
 	"strings"
 	"sync"
 )
@@ -21,33 +20,49 @@ type InfoFrame struct {
 	positions        []Item
 	nsItems          []Namespace
 	nameColWidth     int
-	readyColWidth    int
-	statusColWidth   int
-	restartsColWidth int
-	ageColWidth      int
+	// columns are pod columns shown after NAME column, colWidths are their widths updated from content on refresh.
+	columns   []podColumn
+	colWidths []int
+	// xOffset is horizontal scroll, used when columns do not fit the frame.
+	xOffset     int
+	showMetrics bool
+	grouping    []groupingRule
+	// unhealthyOnly hides healthy pods and groups, healthy namespaces are shown as a single summary line.
+	unhealthyOnly bool
+	// hiddenGroups and hiddenPods are numbers of healthy groups and pods hidden by unhealthyOnly, updated with
+	// positions. They do not depend on expanded items, healthy namespaces shown as a summary line are not included.
+	hiddenGroups int
+	hiddenPods   int
+	// filter is set from search input, nil when search is not active.
+	filter *filter
+	// matchPositions are indexes in positions of items matched by filter.
+	matchPositions []int
+	// sortMode is applied to pod groups and pods of every namespace when it is updated.
+	sortMode sortMode
+	// marked pods are targets of exec, logs and clipboard shortcuts instead of the item under cursor.
+	marked map[podKey]struct{}
 }
 
 func NewInfoFrame(winWidth, winHeight int) *InfoFrame {
 	podHeader := StringItem{0, 3, 0, "NAME  READY  STATUS  RESTARTS  AGE"}
-	width, height := calcInfoFrameSize(winWidth, winHeight)
+	width, height := calcInfoFrameSize(winWidth, winHeight, 0)
+	columns, _ := getPodColumns(map[string]interface{}{}, false)
 
 	return &InfoFrame{
-		x:                0,
-		y:                MainFrameStartY,
-		width:            width,
-		height:           height,
-		cursorX:          0,
-		cursorY:          0,
-		scrollYOffset:    0,
-		expandLevel:      0,
-		podHeader:        podHeader,
-		positions:        []Item{},
-		nsItems:          []Namespace{},
-		nameColWidth:     NameColumnDefaultWidth,
-		readyColWidth:    ReadyColumnDefaultWidth,
-		statusColWidth:   StatusColumnDefaultWidth,
-		restartsColWidth: RestartsColumnDefaultWidth,
-		ageColWidth:      AgeColumnDefaultWidth,
+		x:             0,
+		y:             MainFrameStartY,
+		width:         width,
+		height:        height,
+		cursorX:       0,
+		cursorY:       0,
+		scrollYOffset: 0,
+		expandLevel:   0,
+		podHeader:     podHeader,
+		positions:     []Item{},
+		nsItems:       []Namespace{},
+		nameColWidth:  NameColumnDefaultWidth,
+		columns:       columns,
+		grouping:      defaultGroupingRules(),
 	}
 }
 
@@ -61,89 +76,197 @@ func (f *InfoFrame) refresh(s tcell.Screen) {
 
 func (f *InfoFrame) clear(s tcell.Screen) {
 	for y := 0; y <= f.height-1; y++ {
-		for x := 0; x <= f.width; x++ {
+		for x := 0; x < f.width; x++ {
 			s.SetContent(x+f.x, y+f.y, ' ', nil, tcell.StyleDefault)
 		}
 	}
 }
 
+// updatePositions builds a flat list of displayed items. When filter is set only matching items, their ancestors
+// (expanded regardless of their state) and their descendants are included.
 func (f *InfoFrame) updatePositions() {
 	positions := make([]Item, 0)
+	f.hiddenGroups = 0
+	f.hiddenPods = 0
+	f.matchPositions = make([]int, 0)
 	for nsIndex := range f.nsItems {
-		positions = append(positions, &f.nsItems[nsIndex])
-		if f.nsItems[nsIndex].isExpanded {
-			if f.nsItems[nsIndex].nsMessage.message != "" {
-				positions = append(positions, &(f.nsItems[nsIndex].nsMessage))
-			}
-			if f.nsItems[nsIndex].nsError.error != nil {
-				positions = append(positions, &(f.nsItems[nsIndex].nsError))
+		if f.unhealthyOnly && !f.isSummaryOnly(&f.nsItems[nsIndex]) {
+			f.countHidden(&f.nsItems[nsIndex])
+		}
+		positions = f.appendNamespacePositions(positions, &f.nsItems[nsIndex])
+	}
+	f.positions = positions
+}
+
+// appendMatchPosition appends item to positions and remembers its position when it is matched by filter.
+func (f *InfoFrame) appendMatchPosition(positions []Item, item Item, matched bool) []Item {
+	if matched {
+		f.matchPositions = append(f.matchPositions, len(positions))
+	}
+	return append(positions, item)
+}
+
+func (f *InfoFrame) appendNamespacePositions(positions []Item, ns *Namespace) []Item {
+	matched := f.filter.matches(ns.DisplayName())
+	// Namespace is displayed for matches in its children only when some of them are displayed.
+	if f.filter != nil && !matched && (f.isSummaryOnly(ns) || !f.filter.namespaceHasMatch(ns, f.unhealthyOnly)) {
+		return positions
+	}
+	positions = f.appendMatchPosition(positions, ns, matched)
+
+	// Children of matched items, or everything when there is no filter, are displayed as they are.
+	inherited := f.filter == nil || matched
+	if !(ns.isExpanded || !inherited) || f.isSummaryOnly(ns) {
+		return positions
+	}
+	if inherited {
+		if ns.nsMessage.message != "" {
+			positions = append(positions, &ns.nsMessage)
+		}
+		if ns.nsError.error != nil {
+			positions = append(positions, &ns.nsError)
+		}
+		if len(ns.events.events) > 0 || ns.events.err != nil {
+			positions = append(positions, &ns.events)
+			if ns.events.isExpanded {
+				positions = appendEventPositions(positions, ns.events.events)
 			}
+		}
+	}
 
-			for dIndex := range f.nsItems[nsIndex].deployments {
-				positions = append(positions, f.nsItems[nsIndex].deployments[dIndex])
-				if f.nsItems[nsIndex].deployments[dIndex].isExpanded {
-					for pIndex := range f.nsItems[nsIndex].deployments[dIndex].pods {
-						positions = append(positions, &(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex]))
-
-						if f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].isExpanded {
-							for pContainer := range f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].containers {
-								positions = append(positions, &(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].containers[pContainer]))
-							}
-						}
-					}
-				}
+	for dIndex := range ns.deployments {
+		if f.unhealthyOnly && ns.deployments[dIndex].isHealthy() {
+			continue
+		}
+		positions = f.appendPodGroupPositions(positions, ns.deployments[dIndex], inherited)
+	}
+	return positions
+}
+
+func (f *InfoFrame) appendPodGroupPositions(positions []Item, pg *PodGroup, inherited bool) []Item {
+	matched := f.filter.matches(pg.name)
+	if !inherited && !matched && !f.filter.podGroupHasMatch(pg, f.unhealthyOnly) {
+		return positions
+	}
+	positions = f.appendMatchPosition(positions, pg, matched)
+
+	if !pg.isExpanded && (inherited || matched) {
+		return positions
+	}
+	inherited = inherited || matched
+	for pIndex := range pg.pods {
+		if f.unhealthyOnly && pg.pods[pIndex].isHealthy() {
+			continue
+		}
+		positions = f.appendPodPositions(positions, &pg.pods[pIndex], inherited)
+	}
+	if inherited {
+		positions = appendEventPositions(positions, pg.events)
+	}
+	return positions
+}
+
+func (f *InfoFrame) appendPodPositions(positions []Item, p *Pod, inherited bool) []Item {
+	matched := f.filter.matches(p.name)
+	if !inherited && !matched && !f.filter.podHasMatch(p) {
+		return positions
+	}
+	positions = f.appendMatchPosition(positions, p, matched)
+
+	if !p.isExpanded && (inherited || matched) {
+		return positions
+	}
+	inherited = inherited || matched
+	for cIndex := range p.containers {
+		container := &p.containers[cIndex]
+		containerMatched := f.filter.matches(container.name)
+		if !inherited && !containerMatched {
+			continue
+		}
+		positions = f.appendMatchPosition(positions, container, containerMatched)
+		if container.isExpanded {
+			for cdIndex := range container.details {
+				positions = append(positions, &container.details[cdIndex])
 			}
 		}
 	}
-	f.positions = positions
+	if inherited {
+		positions = appendEventPositions(positions, p.events)
+	}
+	return positions
 }
 
-func (f *InfoFrame) updatePodHeader(s tcell.Screen) {
-	f.nameColWidth = NameColumnDefaultWidth
-	f.readyColWidth = ReadyColumnDefaultWidth
-	f.statusColWidth = StatusColumnDefaultWidth
-	f.restartsColWidth = RestartsColumnDefaultWidth
-	f.ageColWidth = AgeColumnDefaultWidth
+// isSummaryOnly returns true when namespace is displayed as a single line regardless of its expanded state.
+func (f *InfoFrame) isSummaryOnly(ns *Namespace) bool {
+	return f.unhealthyOnly && ns.isHealthy()
+}
 
-	for nsIndex := range f.nsItems {
-		if f.nameColWidth < ColumnSpacing+len(f.nsItems[nsIndex].DisplayName()) {
-			f.nameColWidth = ColumnSpacing + len(f.nsItems[nsIndex].DisplayName())
+// countHidden adds healthy groups and pods of the namespace to hidden counts.
+func (f *InfoFrame) countHidden(ns *Namespace) {
+	for _, pg := range ns.deployments {
+		if pg.isHealthy() {
+			f.hiddenGroups++
 		}
-		if f.nsItems[nsIndex].IsExpanded() {
-			for dIndex := range f.nsItems[nsIndex].deployments {
-				if f.nameColWidth < PodGroupXOffset+ColumnSpacing+len(f.nsItems[nsIndex].deployments[dIndex].name) {
-					f.nameColWidth = PodGroupXOffset + ColumnSpacing + len(f.nsItems[nsIndex].deployments[dIndex].name)
-				}
-				if f.nsItems[nsIndex].deployments[dIndex].isExpanded {
-					for pIndex := range f.nsItems[nsIndex].deployments[dIndex].pods {
-						if f.nameColWidth < PodXOffset+ColumnSpacing+len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].name) {
-							f.nameColWidth = PodXOffset + ColumnSpacing + len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].name)
-						}
-						if f.readyColWidth < ColumnSpacing+len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].ReadyString()) {
-							f.readyColWidth = ColumnSpacing + len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].ReadyString())
-						}
-						if f.statusColWidth < ColumnSpacing+len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].status) {
-							f.statusColWidth = ColumnSpacing + len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].status)
-						}
-						if f.restartsColWidth < ColumnSpacing+len(strconv.Itoa(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].restarts)) {
-							f.restartsColWidth = ColumnSpacing + len(strconv.Itoa(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].restarts))
-						}
-						if f.ageColWidth < ColumnSpacing+len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].age) {
-							f.ageColWidth = ColumnSpacing + len(f.nsItems[nsIndex].deployments[dIndex].pods[pIndex].age)
-						}
-					}
-				}
+		for pIndex := range pg.pods {
+			if pg.pods[pIndex].isHealthy() {
+				f.hiddenPods++
 			}
 		}
 	}
+}
+
+// filterInfo returns description of active filters displayed in the header, empty when nothing is filtered.
+func (f *InfoFrame) filterInfo() string {
+	infos := make([]string, 0, 2)
+	if f.unhealthyOnly {
+		infos = append(infos, fmt.Sprintf("[unhealthy only, %d healthy groups and %d pods hidden]", f.hiddenGroups, f.hiddenPods))
+	}
+	if f.filter != nil {
+		infos = append(infos, fmt.Sprintf("[filter '%v', %d matches]", f.filter.pattern, len(f.matchPositions)))
+	}
+	return strings.Join(infos, " ")
+}
 
-	toPrint :=
-		"NAME" + strings.Repeat(" ", f.nameColWidth-4) +
-			"READY" + strings.Repeat(" ", f.readyColWidth-5) +
-			"STATUS" + strings.Repeat(" ", f.statusColWidth-6) +
-			"RESTARTS" + strings.Repeat(" ", f.restartsColWidth-8) +
-			"AGE"
-	f.podHeader.Update(s, toPrint)
+func appendEventPositions(positions []Item, events []Event) []Item {
+	for eIndex := range events {
+		positions = append(positions, &events[eIndex])
+	}
+	return positions
+}
+
+func (f *InfoFrame) updatePodHeader(s tcell.Screen) {
+	f.updateColumnWidths()
+	f.clampXOffset()
+
+	header := f.columnsHeader()
+	if f.xOffset < len(header) {
+		header = header[f.xOffset:]
+	} else {
+		header = ""
+	}
+	toPrint := header + fmt.Sprintf("[sort: %v]", f.sortMode)
+	if info := f.markInfo(); info != "" {
+		toPrint += " " + info
+	}
+	if info := f.filterInfo(); info != "" {
+		toPrint += " " + info
+	}
+	f.podHeader.UpdateS(s, toPrint, activeTheme.style(StateHeader))
+}
+
+// rowEnd returns content position of the right frame border, it is used as length of values filling the rest of a row.
+func (f *InfoFrame) rowEnd(x int) int {
+	return f.xOffset + f.width - x
+}
+
+// printMetrics draws cpu and memory in their columns, when they are displayed.
+func (f *InfoFrame) printMetrics(s tcell.Screen, cpu, memory string, yPos int, style tcell.Style) {
+	if x, ok := f.columnPos(ColumnCPU); ok {
+		f.drawCell(s, cpu, x, yPos, len(cpu), style)
+	}
+	if x, ok := f.columnPos(ColumnMemory); ok {
+		f.drawCell(s, memory, x, yPos, len(memory), style)
+	}
 }
 
 func (f *InfoFrame) updateFrameInfo(s tcell.Screen) {
@@ -164,6 +287,15 @@ func (f *InfoFrame) updateFrameInfo(s tcell.Screen) {
 			f.printPod(s, position.(*Pod), posIndex)
 		case TypeContainer:
 			f.printContainer(s, position.(*Container), posIndex)
+		case TypeEvents:
+			f.printEvents(s, position.(*Events), posIndex)
+		case TypeEvent:
+			f.printEvent(s, position.(*Event), posIndex)
+		case TypeContainerDetail:
+			f.printContainerDetail(s, position.(*ContainerDetail), posIndex)
+		}
+		if f.filter != nil {
+			f.highlightMatch(s, position, posIndex)
 		}
 	}
 }
@@ -171,88 +303,134 @@ func (f *InfoFrame) updateFrameInfo(s tcell.Screen) {
 func (f *InfoFrame) printNamespace(s tcell.Screen, ns *Namespace, yPos int) {
 	style := tcell.StyleDefault
 
-	if !ns.isExpanded {
+	if !ns.isExpanded || f.isSummaryOnly(ns) {
 		readyCount := 0
 		totalCount := 0
 		for dIndex := range ns.deployments {
-			totalCount += len(ns.deployments[dIndex].pods)
+			totalCount += ns.deployments[dIndex].countActivePods()
 			readyCount += ns.deployments[dIndex].countReadyPods()
 		}
+		state := StateHealthy
 		if readyCount != totalCount || ns.nsError.error != nil {
-			style = style.Foreground(tcell.ColorRed)
+			state = StateFailed
+			style = activeTheme.style(StateFailed)
 		}
 		readyColPos := f.nameColWidth - NamespaceXOffset
-		drawS(s, ns.DisplayName(), NamespaceXOffset, f.y+yPos, readyColPos, style)
-		drawS(s, fmt.Sprintf("%v/%v", readyCount, totalCount), readyColPos, f.y+yPos, f.width-readyColPos, style)
+		f.drawCell(s, ns.DisplayName()+activeTheme.symbol(state), NamespaceXOffset, yPos, readyColPos, style)
+		f.drawCell(s, fmt.Sprintf("%v/%v", readyCount, totalCount), readyColPos, yPos, f.rowEnd(readyColPos), style)
 	} else {
-		drawS(s, ns.DisplayName(), NamespaceXOffset, f.y+yPos, f.width-NamespaceXOffset, style)
+		f.drawCell(s, ns.DisplayName(), NamespaceXOffset, yPos, f.rowEnd(NamespaceXOffset), style)
+	}
+	if ns.reconnecting {
+		// Marker is placed after ready counts in the first column, so it does not hide any information.
+		markerPos := f.firstColumnEnd()
+		f.drawCell(s, "(reconnecting)", markerPos, yPos, f.rowEnd(markerPos), activeTheme.style(StateWarning))
 	}
 }
 
 func (f *InfoFrame) printNamespaceError(s tcell.Screen, nse *NamespaceError, yPos int) {
-	drawS(s, nse.error.Error(), NamespaceErrorXOffset, f.y+yPos, f.width, tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	f.drawCell(s, nse.error.Error(), NamespaceErrorXOffset, yPos, f.rowEnd(NamespaceErrorXOffset), activeTheme.style(StateWarning))
 }
 
 func (f *InfoFrame) printNamespaceMessage(s tcell.Screen, nse *NamespaceMessage, yPos int) {
-	drawS(s, nse.message, NamespaceMessageXOffset, f.y+yPos, f.width, tcell.StyleDefault.Foreground(tcell.ColorYellow))
+	f.drawCell(s, nse.message, NamespaceMessageXOffset, yPos, f.rowEnd(NamespaceMessageXOffset), activeTheme.style(StateWarning))
 }
 
 func (f *InfoFrame) printPodGroup(s tcell.Screen, d *PodGroup, yPos int) {
 	style := tcell.StyleDefault
+	degraded := d.workload != nil && d.workload.isDegraded()
 
+	readyColPos := f.nameColWidth - NamespaceXOffset
 	if !d.isExpanded {
-		total := len(d.pods)
+		total := d.countActivePods()
 		ready := d.countReadyPods()
-		if total != ready {
-			style = style.Foreground(tcell.ColorRed)
-		} else {
-			style = style.Foreground(tcell.ColorGreen)
+		state := StateHealthy
+		if total != ready || degraded {
+			state = StateFailed
 		}
+		style = activeTheme.style(state)
 
-		readyColPos := f.nameColWidth - NamespaceXOffset
-		drawS(s, d.name, PodGroupXOffset, f.y+yPos, readyColPos, style)
-		drawS(s, fmt.Sprintf("%v/%v", ready, total), readyColPos, f.y+yPos, f.width-readyColPos, style)
+		f.drawCell(s, d.name+activeTheme.symbol(state), PodGroupXOffset, yPos, readyColPos, style)
+		f.drawCell(s, fmt.Sprintf("%v/%v", ready, total), readyColPos, yPos, f.rowEnd(readyColPos), style)
 	} else {
-		drawS(s, d.name, PodGroupXOffset, f.y+yPos, f.width, style)
+		f.drawCell(s, d.name, PodGroupXOffset, yPos, f.rowEnd(PodGroupXOffset), style)
+	}
+
+	// Replica counts are placed in the status column, after ready counts of collapsed groups.
+	statusColPos := f.statusPos(f.nameColWidth)
+	if !d.isExpanded {
+		statusColPos = f.statusPos(f.firstColumnEnd())
+	}
+	if d.workload != nil {
+		workloadStyle := style
+		if degraded {
+			workloadStyle = activeTheme.style(StateFailed)
+		}
+		f.drawCell(s, d.workload.DisplayName(), statusColPos, yPos, f.rowEnd(statusColPos), workloadStyle)
+	} else if info := d.workloadUnavailable(); info != "" {
+		f.drawCell(s, info, statusColPos, yPos, f.rowEnd(statusColPos), activeTheme.style(StateWarning))
 	}
 }
 
 func (f *InfoFrame) printPod(s tcell.Screen, p *Pod, yPos int) {
 	running := p.status == "Running"
 	style := tcell.StyleDefault
+	symbol := ""
 	if !p.isExpanded {
-		if running && p.ready >= p.total {
-			style = style.Foreground(tcell.ColorGreen)
+		state := StateFailed
+		if (running && p.ready >= p.total) || p.isCompleted() {
+			state = StateHealthy
 		} else if running && p.ready < p.total {
-			style = style.Foreground(tcell.ColorYellow)
-		} else {
-			style = style.Foreground(tcell.ColorRed)
+			state = StateDegraded
 		}
+		style = activeTheme.style(state)
+		symbol = activeTheme.symbol(state)
 	}
 
-	xOffset := PodXOffset
-	drawS(s, p.name, xOffset, f.y+yPos, f.nameColWidth, style)
-	xOffset += f.nameColWidth - PodXOffset
-	drawS(s, p.ReadyString(), xOffset, f.y+yPos, f.readyColWidth, style)
-	xOffset += f.readyColWidth
-	drawS(s, p.status, xOffset, f.y+yPos, f.statusColWidth, style)
-	xOffset += f.statusColWidth
-	drawS(s, strconv.Itoa(p.restarts), xOffset, f.y+yPos, f.restartsColWidth, style)
-	xOffset += f.restartsColWidth
-	drawS(s, p.age, xOffset, f.y+yPos, f.width-xOffset, style)
+	if f.isMarked(p) {
+		f.drawCell(s, MarkSymbol, PodXOffset-1, yPos, 1, style)
+	}
+	f.drawCell(s, p.name+symbol, PodXOffset, yPos, f.nameColWidth-PodXOffset, style)
+	xOffset := f.nameColWidth
+	for index, column := range f.columns {
+		f.drawCell(s, column.value(p), xOffset, yPos, f.colWidths[index], style)
+		xOffset += f.colWidths[index]
+	}
 }
 
 func (f *InfoFrame) printContainer(s tcell.Screen, c *Container, yPos int) {
-	style := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	state := StateHealthy
 	if !c.ready {
-		style = style.Foreground(tcell.ColorRed)
+		state = StateFailed
+	}
+	style := activeTheme.style(state)
+
+	name := c.DisplayName() + activeTheme.symbol(state)
+	f.drawCell(s, name, ContainerXOffset, yPos, f.rowEnd(ContainerXOffset), style)
+	// State is placed in status column, right after the name column when status is not displayed.
+	statusColPos := f.statusPos(f.nameColWidth)
+	f.drawCell(s, c.StatusString(), statusColPos, yPos, f.rowEnd(statusColPos), style)
+	if f.hasMetricsColumns() {
+		cpu, memory := c.metricsCells()
+		f.printMetrics(s, cpu, memory, yPos, style)
 	}
+}
+
+func (f *InfoFrame) printContainerDetail(s tcell.Screen, cd *ContainerDetail, yPos int) {
+	f.drawCell(s, cd.DisplayName(), ContainerDetailXOffset, yPos, f.rowEnd(ContainerDetailXOffset), tcell.StyleDefault)
+}
 
-	drawS(s, c.DisplayName(), ContainerXOffset, f.y+yPos, f.width-ContainerXOffset, style)
+func (f *InfoFrame) printEvents(s tcell.Screen, e *Events, yPos int) {
+	f.drawCell(s, e.DisplayName(), EventsXOffset, yPos, f.rowEnd(EventsXOffset), activeTheme.style(StateWarning))
 }
 
-// updateNamespaces will get all expanded item names, apply expanded flag on new namespaces infos and replace existing f.nsItems with
-// new namespaces.
+func (f *InfoFrame) printEvent(s tcell.Screen, e *Event, yPos int) {
+	xOffset := e.level * 2
+	f.drawCell(s, e.DisplayName(), xOffset, yPos, f.rowEnd(xOffset), activeTheme.style(StateWarning))
+}
+
+// updateNamespaces will get all expanded item names, replace matching namespaces in f.nsItems with new namespace infos
+// and apply expanded flag on them. Namespaces without a new result are kept as they are.
 // frame positions will need to be updated straight after to avoid errors.
 func (f *InfoFrame) updateNamespaces(podListResults []PodListResult) {
 	expanded := make(map[string]struct{}, 0)
@@ -262,6 +440,9 @@ func (f *InfoFrame) updateNamespaces(podListResults []PodListResult) {
 		if f.nsItems[nsIndex].IsExpanded() {
 			expanded[nsDisplayName] = struct{}{}
 		}
+		if f.nsItems[nsIndex].events.IsExpanded() {
+			expanded[nsDisplayName+"/events"] = struct{}{}
+		}
 		for dIndex := range f.nsItems[nsIndex].deployments {
 			deploymentName := f.nsItems[nsIndex].deployments[dIndex].name
 			if f.nsItems[nsIndex].deployments[dIndex].isExpanded {
@@ -269,16 +450,38 @@ func (f *InfoFrame) updateNamespaces(podListResults []PodListResult) {
 				expanded[nsDisplayName+deploymentName] = struct{}{}
 			}
 			for podIndex := range f.nsItems[nsIndex].deployments[dIndex].pods {
-				if f.nsItems[nsIndex].deployments[dIndex].pods[podIndex].IsExpanded() {
-					expanded[f.nsItems[nsIndex].deployments[dIndex].pods[podIndex].name] = struct{}{}
+				pod := &f.nsItems[nsIndex].deployments[dIndex].pods[podIndex]
+				if pod.IsExpanded() {
+					expanded[pod.name] = struct{}{}
+				}
+				for cIndex := range pod.containers {
+					if pod.containers[cIndex].IsExpanded() {
+						expanded[pod.name+"/"+pod.containers[cIndex].name] = struct{}{}
+					}
 				}
 			}
 		}
 	}
 
-	newNamespaces := make([]Namespace, len(podListResults))
-	for index, plr := range podListResults {
-		newNamespaces[index] = toNamespace(&plr)
+	updated := make(map[string]Namespace, len(podListResults))
+	for index := range podListResults {
+		ns := toNamespace(&podListResults[index], f.grouping)
+		sortNamespace(&ns, f.sortMode)
+		updated[ns.DisplayName()] = ns
+	}
+
+	newNamespaces := make([]Namespace, 0, len(f.nsItems)+len(updated))
+	for nsIndex := range f.nsItems {
+		nsDisplayName := f.nsItems[nsIndex].DisplayName()
+		if ns, ok := updated[nsDisplayName]; ok {
+			newNamespaces = append(newNamespaces, ns)
+			delete(updated, nsDisplayName)
+		} else {
+			newNamespaces = append(newNamespaces, f.nsItems[nsIndex])
+		}
+	}
+	for _, ns := range updated {
+		newNamespaces = append(newNamespaces, ns)
 	}
 
 	sort.Slice(newNamespaces, func(i, j int) bool {
@@ -297,6 +500,9 @@ func (f *InfoFrame) updateNamespaces(podListResults []PodListResult) {
 		if ok {
 			newNamespaces[nsIndex].isExpanded = true
 		}
+		if _, ok := expanded[nsDisplayName+"/events"]; ok {
+			newNamespaces[nsIndex].events.Expanded(true)
+		}
 		for dIndex := range newNamespaces[nsIndex].deployments {
 			deploymentName := newNamespaces[nsIndex].deployments[dIndex].name
 			_, ok := expanded[nsDisplayName+deploymentName]
@@ -304,9 +510,14 @@ func (f *InfoFrame) updateNamespaces(podListResults []PodListResult) {
 				newNamespaces[nsIndex].deployments[dIndex].Expanded(true)
 			}
 			for podIndex := range newNamespaces[nsIndex].deployments[dIndex].pods {
-				_, ok := expanded[newNamespaces[nsIndex].deployments[dIndex].pods[podIndex].name]
-				if ok {
-					newNamespaces[nsIndex].deployments[dIndex].pods[podIndex].isExpanded = true
+				pod := &newNamespaces[nsIndex].deployments[dIndex].pods[podIndex]
+				if _, ok := expanded[pod.name]; ok {
+					pod.isExpanded = true
+				}
+				for cIndex := range pod.containers {
+					if _, ok := expanded[pod.name+"/"+pod.containers[cIndex].name]; ok {
+						pod.containers[cIndex].isExpanded = true
+					}
 				}
 			}
 		}
@@ -387,7 +598,7 @@ func (f *InfoFrame) collapseCurrentItem(s tcell.Screen) {
 		counter := 0
 		for i := fullPos; i > 0; i-- {
 			counter++
-			if item.Type() > f.positions[fullPos-counter].Type() {
+			if item.Level() > f.positions[fullPos-counter].Level() {
 				break
 			}
 		}
@@ -407,6 +618,17 @@ func (f *InfoFrame) expandCurrentItem(s tcell.Screen) {
 	}
 }
 
+// cycleSortMode switches to the next sort mode and reorders all namespaces.
+func (f *InfoFrame) cycleSortMode() {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+
+	f.sortMode = f.sortMode.next()
+	for nsIndex := range f.nsItems {
+		sortNamespace(&f.nsItems[nsIndex], f.sortMode)
+	}
+}
+
 func (f *InfoFrame) applyExpandLevel() {
 	f.Mutex.Lock()
 	defer f.Mutex.Unlock()
@@ -417,6 +639,7 @@ func (f *InfoFrame) applyExpandLevel() {
 		} else {
 			f.nsItems[nsIndex].Expanded(false)
 		}
+		f.nsItems[nsIndex].events.Expanded(f.expandLevel >= 2)
 
 		for gIndex := range f.nsItems[nsIndex].deployments {
 			if f.expandLevel >= 2 {
@@ -479,14 +702,15 @@ func (f *InfoFrame) pageDown(s tcell.Screen) {
 	f.updateCursor(s)
 }
 
-func (f *InfoFrame) resize(s tcell.Screen, winWidth, winHeight int) {
-	width, height := calcInfoFrameSize(winWidth, winHeight)
+func (f *InfoFrame) resize(s tcell.Screen, winWidth, winHeight, sidePanelWidth int) {
+	width, height := calcInfoFrameSize(winWidth, winHeight, sidePanelWidth)
 	f.width = width
 	f.height = height
 	f.refresh(s)
 }
 
-// calcSize will return frame size relatively to terminal window size and frame position.
-func calcInfoFrameSize(winWidth, winHeight int) (width, height int) {
-	return winWidth, winHeight - MainFrameStartY - FooterFrameHeight
+// calcSize will return frame size relatively to terminal window size and frame position, sidePanelWidth is taken by
+// DetailsFrame when it is visible.
+func calcInfoFrameSize(winWidth, winHeight, sidePanelWidth int) (width, height int) {
+	return winWidth - sidePanelWidth, winHeight - MainFrameStartY - FooterFrameHeight
 }
@@ -3,6 +3,8 @@ package app
 import (
 	"fmt"
 	"github.com/gdamore/tcell/v2"
+	v1 "k8s.io/api/core/v1"
+	"reflect"
 	"strconv"
 	"testing"
 )
@@ -102,7 +104,8 @@ func TestMoveCursor(t *testing.T) {
 		},
 	}
 
-	for index, tc := range testTable {
+	for index := range testTable {
+		tc := &testTable[index]
 		t.Run(fmt.Sprintf("%v %v", index, tc.name), func(t *testing.T) {
 			tc.frame.updatePositions()
 			tc.frame.moveCursor(screen, tc.moveBy)
@@ -116,6 +119,87 @@ func TestMoveCursor(t *testing.T) {
 	}
 }
 
+func TestUpdateNamespaces(t *testing.T) {
+	frame := InfoFrame{}
+	frame.updateNamespaces([]PodListResult{
+		{context: "context", namespace: "ns1"},
+		{context: "context", namespace: "ns2"},
+	})
+	frame.nsItems[0].Expanded(true)
+
+	frame.updateNamespaces([]PodListResult{
+		{context: "context", namespace: "ns1", reconnecting: true},
+		{context: "context", namespace: "ns3"},
+	})
+
+	if len(frame.nsItems) != 3 {
+		t.Fatalf("Invalid namespace count. Want: %v, Got: %v", 3, len(frame.nsItems))
+	}
+	for index, name := range []string{"ns1", "ns2", "ns3"} {
+		if frame.nsItems[index].name != name {
+			t.Errorf("Invalid namespace at %v. Want: %v, Got: %v", index, name, frame.nsItems[index].name)
+		}
+	}
+	if !frame.nsItems[0].isExpanded || !frame.nsItems[0].reconnecting {
+		t.Errorf("Updated namespace should keep expanded flag and get reconnecting flag, Got: %+v", frame.nsItems[0])
+	}
+}
+
+func TestUnhealthyOnlyPositions(t *testing.T) {
+	healthy := Pod{name: "web-1", status: "Running", ready: 1, total: 1}
+	failing := Pod{name: "web-2", status: "CrashLoopBackOff", ready: 0, total: 1}
+	completed := Pod{name: "job-1", status: "Completed", phase: v1.PodSucceeded, ready: 0, total: 1}
+
+	frame := InfoFrame{unhealthyOnly: true}
+	frame.nsItems = []Namespace{
+		{name: "healthy", context: "context", isExpanded: true, deployments: []*PodGroup{
+			{name: "web", isExpanded: true, pods: []Pod{healthy}},
+			{name: "job", isExpanded: true, pods: []Pod{completed}},
+		}},
+		{name: "unhealthy", context: "context", isExpanded: true, deployments: []*PodGroup{
+			{name: "job", isExpanded: true, pods: []Pod{completed}},
+			{name: "web", isExpanded: true, pods: []Pod{healthy, failing}},
+		}},
+	}
+
+	frame.updatePositions()
+
+	expected := []string{"healthy / context", "unhealthy / context", "web", "web-2"}
+	names := make([]string, 0, len(frame.positions))
+	for _, item := range frame.positions {
+		switch i := item.(type) {
+		case *Namespace:
+			names = append(names, i.DisplayName())
+		case *PodGroup:
+			names = append(names, i.name)
+		case *Pod:
+			names = append(names, i.name)
+		}
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Invalid positions. Want: %v, Got: %v", expected, names)
+	}
+	// Healthy namespace is shown as a summary line, so only job group with job-1 and web-1 are hidden.
+	if frame.hiddenGroups != 1 || frame.hiddenPods != 2 {
+		t.Errorf("Invalid hidden counts. Want: 1 group and 2 pods, Got: %v groups and %v pods", frame.hiddenGroups, frame.hiddenPods)
+	}
+	// Counts do not depend on expanded items.
+	frame.nsItems[1].isExpanded = false
+	frame.nsItems[1].deployments[1].isExpanded = false
+	frame.updatePositions()
+	if frame.hiddenGroups != 1 || frame.hiddenPods != 2 {
+		t.Errorf("Hidden counts should not change when collapsed. Want: 1 group and 2 pods, Got: %v groups and %v pods", frame.hiddenGroups, frame.hiddenPods)
+	}
+	frame.nsItems[1].isExpanded = true
+	frame.nsItems[1].deployments[1].isExpanded = true
+
+	frame.unhealthyOnly = false
+	frame.updatePositions()
+	if len(frame.positions) != 11 || frame.filterInfo() != "" {
+		t.Errorf("All items should be displayed without filter, Got: %v positions", len(frame.positions))
+	}
+}
+
 func fakeNamespaces(count int) []Namespace {
 	ns := make([]Namespace, count)
 	for index, _ := range ns {
@@ -4,27 +4,26 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
-	"os"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 	"path/filepath"
-	"sync"
+	"strings"
+	"time"
 )
 
-type clientSetMap map[string]*kubernetes.Clientset
+type clientSetMap map[string]kubernetes.Interface
 
-type Client struct {
-	k8ClientSets clientSetMap
-}
+type metricsClientSetMap map[string]metricsclientset.Interface
 
-type getPodJob struct {
-	context   string
-	namespace string
+type Client struct {
+	k8ClientSets      clientSetMap
+	metricsClientSets metricsClientSetMap
 }
 
 type PodListResult struct {
@@ -32,17 +31,35 @@ type PodListResult struct {
 	namespace string
 	v1.PodList
 	error
+	reconnecting bool
+	duration     time.Duration
+	nextRetry    time.Time
+	// owners are top level pod controllers keyed by pod name.
+	owners         map[string]podOwner
+	events         []v1.Event
+	eventsError    error
+	workloads      map[string]workloadStatus
+	workloadsError error
+	// metrics are keyed by pod name and container name.
+	metrics      map[string]map[string]containerUsage
+	metricsError error
 }
 
-func NewK8ClientSets(contexts map[string]struct{}) (Client, error) {
-	configPath, err := configPath()
-	if err != nil {
-		return Client{}, err
-	}
+// DisplayName matches Namespace.DisplayName, so results can be matched with already displayed namespaces.
+func (plr *PodListResult) DisplayName() string {
+	return nsDisplayName(plr.namespace, plr.context)
+}
 
-	k8ClientSets := make(map[string]*kubernetes.Clientset)
+// InClusterContext is used as a context name when running inside the cluster without any kubeconfig.
+const InClusterContext = "in-cluster"
+
+// NewK8ClientSets creates a clientset for every context, kubeconfig is loaded with standard rules: explicit kubeconfig
+// path if provided, otherwise files listed in KUBECONFIG merged together, otherwise ~/.kube/config.
+func NewK8ClientSets(kubeconfig string, contexts map[string]struct{}) (Client, error) {
+	k8ClientSets := make(clientSetMap)
+	metricsClientSets := make(metricsClientSetMap)
 	for ctx := range contexts {
-		config, err := buildConfigFromFlags(ctx, configPath)
+		config, err := buildConfigFromFlags(ctx, kubeconfig)
 		if err != nil {
 			return Client{}, errors.Wrapf(err, "Error creating client config for context: %v", ctx)
 		}
@@ -52,65 +69,173 @@ func NewK8ClientSets(contexts map[string]struct{}) (Client, error) {
 			return Client{}, errors.Wrapf(err, "Error creating clientset for context: %v", ctx)
 		}
 		k8ClientSets[ctx] = k8client
+
+		metricsClient, err := metricsclientset.NewForConfig(config)
+		if err != nil {
+			return Client{}, errors.Wrapf(err, "Error creating metrics clientset for context: %v", ctx)
+		}
+		metricsClientSets[ctx] = metricsClient
 	}
 
-	return Client{k8ClientSets: k8ClientSets}, nil
+	return Client{k8ClientSets: k8ClientSets, metricsClientSets: metricsClientSets}, nil
 }
 
-func CurrentContextName() (string, error) {
-	configPath, err := configPath()
+// CurrentContextName returns current context from kubeconfig, when there is no kubeconfig but app is running inside
+// the cluster InClusterContext is returned.
+func CurrentContextName(kubeconfig string) (string, error) {
+	rules := loadingRules(kubeconfig)
+	config, err := rules.Load()
 	if err != nil {
-		return "", err
+		return "", errors.Wrapf(err, "error loading config from %v", strings.Join(rules.GetLoadingPrecedence(), string(filepath.ListSeparator)))
 	}
+	if config.CurrentContext == "" {
+		if _, err := rest.InClusterConfig(); err == nil {
+			return InClusterContext, nil
+		}
+		return "", errors.New(fmt.Sprintf("No current context found in '%v'", strings.Join(rules.GetLoadingPrecedence(), string(filepath.ListSeparator))))
+	}
+	return config.CurrentContext, nil
+}
 
-	configRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: configPath}
-	config, err := configRules.Load()
+// watchPods will start a podWatcher for every context/namespace pair in the group, updates are sent to updateCh
+// until ctx is cancelled. Interval is used as a starting delay when retrying failed namespaces.
+func (k8Client Client) watchPods(ctx context.Context, group Group, interval time.Duration, updateCh chan<- PodListResult) {
+	for gIndex := range group.NsGroups {
+		ctxName := group.NsGroups[gIndex].Context
+		for nsIndex := range group.NsGroups[gIndex].Namespaces {
+			w := newPodWatcher(ctxName, group.NsGroups[gIndex].Namespaces[nsIndex], k8Client.k8ClientSets[ctxName], interval, updateCh)
+			w.labelSelector = group.NsGroups[gIndex].LabelSelector
+			w.fieldSelector = group.NsGroups[gIndex].FieldSelector
+			go w.run(ctx)
+		}
+	}
+}
+
+// warningEvents lists Warning type events in a namespace.
+func (k8Client Client) warningEvents(ctx context.Context, ctxName, namespace string) ([]v1.Event, error) {
+	eventList, err := k8Client.k8ClientSets[ctxName].CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + v1.EventTypeWarning})
 	if err != nil {
-		return "", errors.Wrapf(err, "error loading default config from %v", configPath)
+		return nil, err
 	}
-	return config.CurrentContext, nil
+	return eventList.Items, nil
 }
 
-func (k8Client Client) podLists(group Group) []PodListResult {
-	var wg sync.WaitGroup
-	resultCh := make(chan PodListResult)
-	jobCh := make(chan getPodJob)
-	workerCount := 3
+// groupDetails fetches warning events and pod metrics, when enabled, and controllers of given kinds for every
+// context/namespace pair in the group, result is keyed by namespace display name. Errors are kept, so missing events,
+// metrics and controller status can be displayed.
+func (k8Client Client) groupDetails(ctx context.Context, group Group, showEvents, showMetrics bool, kinds map[string]map[string]struct{}) map[string]namespaceDetails {
+	result := make(map[string]namespaceDetails)
+	for gIndex := range group.NsGroups {
+		ctxName := group.NsGroups[gIndex].Context
+		for _, namespace := range group.NsGroups[gIndex].Namespaces {
+			displayName := nsDisplayName(namespace, ctxName)
+			var details namespaceDetails
+			if showEvents {
+				details.events, details.eventsError = k8Client.warningEvents(ctx, ctxName, namespace)
+			}
+			if showMetrics {
+				details.metrics, details.metricsError = k8Client.podMetrics(ctx, ctxName, namespace, group.NsGroups[gIndex].LabelSelector)
+			}
+			details.workloads, details.workloadsError = k8Client.namespaceWorkloads(ctx, ctxName, namespace, kinds[displayName])
+			result[displayName] = details
+		}
+	}
+	return result
+}
 
-	wg.Add(workerCount)
-	for i := 0; i < workerCount; i++ {
-		go getPods(k8Client, jobCh, resultCh, &wg)
+// podMetrics lists pod metrics from metrics.k8s.io, this will fail when metrics-server is not available in the context.
+func (k8Client Client) podMetrics(ctx context.Context, ctxName, namespace, labelSelector string) (map[string]map[string]containerUsage, error) {
+	metricsList, err := k8Client.metricsClientSets[ctxName].MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
 	}
+	return toPodUsages(metricsList.Items), nil
+}
+
+// namespaceWorkloads lists controllers of given kinds, ReplicaSets owned by a Deployment get status of the Deployment
+// and Jobs owned by a CronJob get status of the CronJob. Result is keyed by controllerKey of the pod controller.
+func (k8Client Client) namespaceWorkloads(ctx context.Context, ctxName, namespace string, kinds map[string]struct{}) (map[string]workloadStatus, error) {
+	appsV1 := k8Client.k8ClientSets[ctxName].AppsV1()
+	result := make(map[string]workloadStatus)
 
-	go func() {
-		for gIndex := range group.NsGroups {
-			for nsIndex := range group.NsGroups[gIndex].Namespaces {
-				jobCh <- getPodJob{
-					context:   group.NsGroups[gIndex].Context,
-					namespace: group.NsGroups[gIndex].Namespaces[nsIndex],
+	if _, ok := kinds[KindReplicaSet]; ok {
+		deployments, err := appsV1.Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return result, err
+		}
+		deploymentStatuses := make(map[string]workloadStatus)
+		for index := range deployments.Items {
+			deploymentStatuses[deployments.Items[index].Name] = deploymentStatus(&deployments.Items[index])
+		}
+
+		replicaSets, err := appsV1.ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return result, err
+		}
+		for index := range replicaSets.Items {
+			rs := &replicaSets.Items[index]
+			key := controllerKey(KindReplicaSet, rs.Name)
+			if ref := controllerRef(rs.OwnerReferences); ref != nil && ref.Kind == KindDeployment {
+				if status, ok := deploymentStatuses[ref.Name]; ok {
+					result[key] = status
+					continue
 				}
 			}
+			result[key] = replicaSetStatus(rs)
 		}
-		close(jobCh)
-	}()
-	go func() {
-		wg.Wait()
-		close(resultCh)
-	}()
+	}
 
-	podListResults := make([]PodListResult, 0)
-	for podListResult := range resultCh {
-		podListResults = append(podListResults, podListResult)
+	if _, ok := kinds[KindStatefulSet]; ok {
+		statefulSets, err := appsV1.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return result, err
+		}
+		for index := range statefulSets.Items {
+			result[controllerKey(KindStatefulSet, statefulSets.Items[index].Name)] = statefulSetStatus(&statefulSets.Items[index])
+		}
 	}
-	return podListResults
-}
 
-func getPods(k8Client Client, jobCh <-chan getPodJob, resultCh chan<- PodListResult, wg *sync.WaitGroup) {
-	for job := range jobCh {
-		podList, err := k8Client.k8ClientSets[job.context].CoreV1().Pods(job.namespace).List(context.Background(), metav1.ListOptions{})
-		resultCh <- PodListResult{job.context, job.namespace, *podList, err}
+	if _, ok := kinds[KindDaemonSet]; ok {
+		daemonSets, err := appsV1.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return result, err
+		}
+		for index := range daemonSets.Items {
+			result[controllerKey(KindDaemonSet, daemonSets.Items[index].Name)] = daemonSetStatus(&daemonSets.Items[index])
+		}
 	}
-	wg.Done()
+
+	if _, ok := kinds[KindJob]; ok {
+		jobs, err := k8Client.k8ClientSets[ctxName].BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return result, err
+		}
+		cronJobJobs := make(map[string][]*batchv1.Job)
+		for index := range jobs.Items {
+			job := &jobs.Items[index]
+			if ref := controllerRef(job.OwnerReferences); ref != nil && ref.Kind == KindCronJob {
+				cronJobJobs[ref.Name] = append(cronJobJobs[ref.Name], job)
+				continue
+			}
+			result[controllerKey(KindJob, job.Name)] = jobStatus(job)
+		}
+
+		if len(cronJobJobs) > 0 {
+			cronJobs, err := k8Client.k8ClientSets[ctxName].BatchV1beta1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return result, err
+			}
+			for index := range cronJobs.Items {
+				cj := &cronJobs.Items[index]
+				status := cronJobStatus(cj, cronJobJobs[cj.Name])
+				for _, job := range cronJobJobs[cj.Name] {
+					result[controllerKey(KindJob, job.Name)] = status
+				}
+			}
+		}
+	}
+
+	return result, nil
 }
 
 func (k8Client Client) listNamespaces(ctxName string) (*v1.NamespaceList, error) {
@@ -118,19 +243,19 @@ func (k8Client Client) listNamespaces(ctxName string) (*v1.NamespaceList, error)
 	return k8Client.k8ClientSets[ctxName].CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
 }
 
-func buildConfigFromFlags(context, kubeconfigPath string) (*rest.Config, error) {
+func buildConfigFromFlags(context, kubeconfig string) (*rest.Config, error) {
+	if context == InClusterContext {
+		return rest.InClusterConfig()
+	}
 	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		loadingRules(kubeconfig),
 		&clientcmd.ConfigOverrides{
 			CurrentContext: context,
 		}).ClientConfig()
 }
 
-func configPath() (string, error) {
-	configPath := filepath.Join(homedir.HomeDir(), ".kube", "config")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return "", errors.New(fmt.Sprintf("No config found in '%v'", configPath))
-	}
-
-	return configPath, nil
+func loadingRules(kubeconfig string) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	return rules
 }
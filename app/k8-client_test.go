@@ -0,0 +1,187 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: %v
+clusters:
+- name: %v
+  cluster:
+    server: https://%v.example.com
+contexts:
+- name: %v
+  context:
+    cluster: %v
+    user: %v
+users:
+- name: %v
+  user:
+    token: token
+`
+
+func TestCurrentContextName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	devPath := writeTestKubeconfig(t, dir, "dev")
+	stagePath := writeTestKubeconfig(t, dir, "stage")
+
+	defer os.Setenv("KUBECONFIG", os.Getenv("KUBECONFIG"))
+	_ = os.Setenv("KUBECONFIG", devPath+string(filepath.ListSeparator)+stagePath)
+
+	testTable := []struct {
+		name       string
+		kubeconfig string
+		expected   string
+	}{
+		{name: "kubeconfig_env_first_file_wins", kubeconfig: "", expected: "dev"},
+		{name: "explicit_kubeconfig", kubeconfig: stagePath, expected: "stage"},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			ctxName, err := CurrentContextName(tc.kubeconfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctxName != tc.expected {
+				t.Errorf("Invalid context name. Want: %v, Got: %v", tc.expected, ctxName)
+			}
+		})
+	}
+
+	// Contexts from all KUBECONFIG files should be available.
+	if _, err := buildConfigFromFlags("stage", ""); err != nil {
+		t.Errorf("Context from second KUBECONFIG file not found: %v", err)
+	}
+}
+
+func writeTestKubeconfig(t *testing.T, dir, name string) string {
+	path := filepath.Join(dir, name)
+	content := fmt.Sprintf(testKubeconfig, name, name, name, name, name, name, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNamespaceWorkloads(t *testing.T) {
+	isController := true
+	webReplicas := int32(5)
+	clientSet := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "ns"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &webReplicas},
+			Status:     appsv1.DeploymentStatus{UpdatedReplicas: 5, AvailableReplicas: 2},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-5d9f8c7b6",
+			Namespace:       "ns",
+			OwnerReferences: []metav1.OwnerReference{{Kind: KindDeployment, Name: "web", Controller: &isController}},
+		}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis-master", Namespace: "ns"},
+			Status:     appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1},
+		},
+	)
+	client := Client{k8ClientSets: clientSetMap{"context": clientSet}}
+
+	kinds := map[string]struct{}{KindReplicaSet: {}, KindStatefulSet: {}}
+	workloads, err := client.namespaceWorkloads(context.Background(), "context", "ns", kinds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]workloadStatus{
+		controllerKey(KindReplicaSet, "web-5d9f8c7b6"): {Kind: KindDeployment, Name: "web", Desired: 5, Updated: 5, Available: 2},
+		controllerKey(KindStatefulSet, "redis-master"): {Kind: KindStatefulSet, Name: "redis-master", Desired: 1, Updated: 1, Available: 1},
+	}
+	if !reflect.DeepEqual(workloads, expected) {
+		t.Errorf("Invalid workloads. Want: %+v, Got: %+v", expected, workloads)
+	}
+	if !workloads[controllerKey(KindReplicaSet, "web-5d9f8c7b6")].isDegraded() {
+		t.Errorf("Deployment with 2 of 5 available replicas should be degraded")
+	}
+}
+
+func TestNamespaceWorkloadsJobs(t *testing.T) {
+	isController := true
+	completions := int32(2)
+	now := time.Now().Truncate(time.Second)
+	scheduled := metav1.NewTime(now.Add(-time.Minute))
+	cronJobRef := []metav1.OwnerReference{{Kind: KindCronJob, Name: "backup", Controller: &isController}}
+	clientSet := fake.NewSimpleClientset(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "ns"},
+			Spec:       batchv1.JobSpec{Completions: &completions},
+			Status:     batchv1.JobStatus{Succeeded: 1, Failed: 2, Active: 1},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-1", Namespace: "ns", OwnerReferences: cronJobRef},
+			Status: batchv1.JobStatus{
+				Succeeded:      1,
+				CompletionTime: &metav1.Time{Time: now.Add(-2 * time.Hour)},
+				Conditions:     []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup-2", Namespace: "ns", OwnerReferences: cronJobRef},
+			Status: batchv1.JobStatus{
+				Failed:     1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Hour))}},
+			},
+		},
+		&batchv1beta1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "ns"},
+			Status:     batchv1beta1.CronJobStatus{LastScheduleTime: &scheduled},
+		},
+	)
+	client := Client{k8ClientSets: clientSetMap{"context": clientSet}}
+
+	workloads, err := client.namespaceWorkloads(context.Background(), "context", "ns", map[string]struct{}{KindJob: {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cronJob := workloadStatus{
+		Kind:           KindCronJob,
+		Name:           "backup",
+		LastSchedule:   scheduled.Time,
+		LastSuccessful: now.Add(-2 * time.Hour),
+		LastJobFailed:  true,
+	}
+	expected := map[string]workloadStatus{
+		controllerKey(KindJob, "migrate"):  {Kind: KindJob, Name: "migrate", Desired: 2, Active: 1, Succeeded: 1, Failed: 2},
+		controllerKey(KindJob, "backup-1"): cronJob,
+		controllerKey(KindJob, "backup-2"): cronJob,
+	}
+	if !reflect.DeepEqual(workloads, expected) {
+		t.Errorf("Invalid workloads. Want: %+v, Got: %+v", expected, workloads)
+	}
+	if !workloads[controllerKey(KindJob, "migrate")].isDegraded() {
+		t.Errorf("Job with failed pods and missing completions should be degraded")
+	}
+	if !workloads[controllerKey(KindJob, "backup-1")].isDegraded() {
+		t.Errorf("CronJob with last Job failed should be degraded")
+	}
+}
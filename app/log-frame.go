@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxLogLines is number of lines kept in LogFrame, older lines are dropped.
+	maxLogLines = 10000
+	// maxLogBatch is the most lines added to LogFrame before it is redrawn.
+	maxLogBatch = 1000
+	logHelp     = "Esc close  f follow  p pause  P previous  s since  t tail  w wrap  / search  arrows/PgUp/PgDn scroll"
+)
+
+// logPrefixColours are used for pod prefixes, so lines from different pods can be told apart.
+var logPrefixColours = []tcell.Color{
+	tcell.ColorGreen, tcell.ColorYellow, tcell.ColorAqua, tcell.ColorFuchsia,
+	tcell.ColorBlue, tcell.ColorOlive, tcell.ColorTeal, tcell.ColorPurple,
+}
+
+// LogFrame streams container logs into a full screen TextView. Logs of multiple containers are interleaved as they
+// come with a coloured 'pod container' prefix.
+type LogFrame struct {
+	sync.Mutex
+	TextView
+	visible bool
+	title   string
+	targets []logTarget
+	// namespacePrefix adds namespace to line prefixes, when targets are from more than one namespace.
+	namespacePrefix bool
+	streamer        logStreamer
+	options         logOptions
+	// sinceIndex and tailIndex point to currently used logSinceOptions and logTailOptions.
+	sinceIndex int
+	tailIndex  int
+	// paused keeps the view unchanged, lines received in the meantime are kept in pending.
+	paused  bool
+	pending []textLine
+	// finished is set when all streams are closed, e.g. logs are not followed or containers are gone.
+	finished bool
+	cancel   context.CancelFunc
+}
+
+func NewLogFrame(winWidth, winHeight int) *LogFrame {
+	lf := &LogFrame{tailIndex: 1}
+	lf.resize(winWidth, winHeight)
+	return lf
+}
+
+// open shows the frame and starts streaming logs of targets, options other than follow are kept from the last time.
+func (lf *LogFrame) open(s tcell.Screen, streamer logStreamer, title string, targets []logTarget, follow bool) {
+	lf.Lock()
+	defer lf.Unlock()
+
+	lf.visible = true
+	lf.streamer = streamer
+	lf.title = title
+	lf.targets = targets
+	lf.namespacePrefix = false
+	for _, target := range targets {
+		if target.context != targets[0].context || target.namespace != targets[0].namespace {
+			lf.namespacePrefix = true
+		}
+	}
+	lf.options.follow = follow
+	lf.options.since = logSinceOptions[lf.sinceIndex]
+	lf.options.tail = logTailOptions[lf.tailIndex]
+	lf.search = nil
+	lf.searchInput = false
+	lf.searchText = ""
+	lf.start(s)
+}
+
+func (lf *LogFrame) close() {
+	lf.Lock()
+	defer lf.Unlock()
+
+	lf.visible = false
+	lf.stop()
+	lf.lines = nil
+	lf.pending = nil
+}
+
+func (lf *LogFrame) stop() {
+	if lf.cancel != nil {
+		lf.cancel()
+		lf.cancel = nil
+	}
+}
+
+// start restarts streaming with current options, lines from the previous stream are removed.
+func (lf *LogFrame) start(s tcell.Screen) {
+	lf.stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	lf.cancel = cancel
+	lf.lines = nil
+	lf.pending = nil
+	lf.paused = false
+	lf.finished = false
+	lf.topRow = 0
+	lf.xOffset = 0
+
+	lineCh := make(chan logLine, 100)
+	go lf.streamer.streamLogs(ctx, lf.targets, lf.options, lineCh)
+	go lf.receive(ctx, s, lineCh)
+	lf.draw(s)
+	s.Show()
+}
+
+// receive adds lines to the frame in batches, so the frame is not redrawn for every single line.
+func (lf *LogFrame) receive(ctx context.Context, s tcell.Screen, lineCh <-chan logLine) {
+	for line := range lineCh {
+		batch := []logLine{line}
+	drain:
+		for len(batch) < maxLogBatch {
+			select {
+			case next, ok := <-lineCh:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+
+		lf.Lock()
+		if ctx.Err() == nil {
+			lf.appendLines(batch)
+			lf.draw(s)
+			s.Show()
+		}
+		lf.Unlock()
+	}
+
+	lf.Lock()
+	if ctx.Err() == nil {
+		lf.finished = true
+		lf.draw(s)
+		s.Show()
+	}
+	lf.Unlock()
+}
+
+func (lf *LogFrame) toTextLine(line logLine) textLine {
+	tl := textLine{
+		text:  strings.ReplaceAll(line.text, "\t", "    "),
+		style: tcell.StyleDefault,
+	}
+	if len(lf.targets) > 1 {
+		tl.prefix = fmt.Sprintf("%v %v ", line.target.pod, line.target.container)
+		if lf.namespacePrefix {
+			tl.prefix = fmt.Sprintf("%v/%v %v ", line.target.namespace, line.target.pod, line.target.container)
+		}
+		tl.prefixStyle = activeTheme.colour(logPrefixColours[line.target.colour%len(logPrefixColours)])
+	}
+	if line.err != nil {
+		tl.text = "Error: " + line.err.Error()
+		tl.style = activeTheme.style(StateError)
+	}
+	return tl
+}
+
+// appendLines adds lines to the view, it is kept scrolled to the end if the last line was visible.
+func (lf *LogFrame) appendLines(lines []logLine) {
+	converted := make([]textLine, 0, len(lines))
+	for _, line := range lines {
+		converted = append(converted, lf.toTextLine(line))
+	}
+
+	if lf.paused {
+		lf.pending = append(lf.pending, converted...)
+		if len(lf.pending) > maxLogLines {
+			lf.pending = lf.pending[len(lf.pending)-maxLogLines:]
+		}
+		return
+	}
+	lf.addLines(converted)
+}
+
+func (lf *LogFrame) addLines(lines []textLine) {
+	atEnd := lf.atEnd()
+	lf.lines = append(lf.lines, lines...)
+	if len(lf.lines) > maxLogLines {
+		lf.dropLines(len(lf.lines) - maxLogLines)
+	}
+	if atEnd {
+		lf.scrollToEnd()
+	}
+}
+
+func (lf *LogFrame) togglePause() {
+	lf.paused = !lf.paused
+	if !lf.paused {
+		lf.addLines(lf.pending)
+		lf.pending = nil
+	}
+}
+
+// handleKey handles all keys while the frame is visible, returns false when the frame was closed.
+func (lf *LogFrame) handleKey(s tcell.Screen, ev *tcell.EventKey) bool {
+	if !lf.searchInput {
+		switch {
+		case ev.Key() == tcell.KeyEscape && lf.search != nil:
+			lf.Lock()
+			lf.search = nil
+			lf.searchText = ""
+			lf.Unlock()
+		case ev.Key() == tcell.KeyEscape || (ev.Key() == tcell.KeyRune && ev.Rune() == 'q'):
+			lf.close()
+			return false
+		case ev.Key() == tcell.KeyRune:
+			lf.Lock()
+			lf.handleOptionKey(s, ev)
+			lf.Unlock()
+		default:
+			lf.Lock()
+			lf.TextView.handleKey(ev)
+			lf.Unlock()
+		}
+	} else {
+		lf.Lock()
+		lf.TextView.handleKey(ev)
+		lf.Unlock()
+	}
+
+	lf.Lock()
+	lf.draw(s)
+	lf.Unlock()
+	s.Show()
+	return true
+}
+
+// handleOptionKey changes stream options, streaming is restarted when any of them is changed.
+func (lf *LogFrame) handleOptionKey(s tcell.Screen, ev *tcell.EventKey) {
+	switch ev.Rune() {
+	case 'f':
+		lf.options.follow = !lf.options.follow
+	case 'P':
+		lf.options.previous = !lf.options.previous
+	case 's':
+		lf.sinceIndex = (lf.sinceIndex + 1) % len(logSinceOptions)
+		lf.options.since = logSinceOptions[lf.sinceIndex]
+	case 't':
+		lf.tailIndex = (lf.tailIndex + 1) % len(logTailOptions)
+		lf.options.tail = logTailOptions[lf.tailIndex]
+	case 'p', ' ':
+		lf.togglePause()
+		return
+	default:
+		lf.TextView.handleKey(ev)
+		return
+	}
+	lf.start(s)
+}
+
+func (lf *LogFrame) resizeFrame(winWidth, winHeight int) {
+	lf.Lock()
+	defer lf.Unlock()
+	lf.resize(winWidth, winHeight)
+	lf.scroll(0)
+}
+
+func (lf *LogFrame) scrollView(s tcell.Screen, n int) {
+	lf.Lock()
+	lf.scroll(n)
+	lf.draw(s)
+	lf.Unlock()
+	s.Show()
+}
+
+func (lf *LogFrame) redraw(s tcell.Screen) {
+	lf.Lock()
+	defer lf.Unlock()
+	lf.draw(s)
+}
+
+func (lf *LogFrame) draw(s tcell.Screen) {
+	lf.TextView.draw(s, fmt.Sprintf("Logs: %v  %v", lf.title, lf.optionsInfo()), logHelp)
+}
+
+// optionsInfo describes stream options and state displayed in the title line.
+func (lf *LogFrame) optionsInfo() string {
+	infos := make([]string, 0)
+	if lf.options.follow {
+		infos = append(infos, "[follow]")
+	}
+	if lf.paused {
+		infos = append(infos, fmt.Sprintf("[paused, %d new lines]", len(lf.pending)))
+	}
+	if lf.options.previous {
+		infos = append(infos, "[previous]")
+	}
+	if lf.options.since > 0 {
+		infos = append(infos, fmt.Sprintf("[since %v]", shortDuration(lf.options.since)))
+	}
+	if lf.options.tail > 0 {
+		infos = append(infos, fmt.Sprintf("[tail %d]", lf.options.tail))
+	} else {
+		infos = append(infos, "[tail all]")
+	}
+	if lf.wrap {
+		infos = append(infos, "[wrap]")
+	}
+	if lf.finished {
+		infos = append(infos, "[end of logs]")
+	}
+	return strings.Join(infos, " ")
+}
+
+// shortDuration formats whole hours and minutes without zero units, e.g. 5m instead of 5m0s.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
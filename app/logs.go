@@ -0,0 +1,159 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	LogViewerApp      = "app"
+	LogViewerTerminal = "terminal"
+	// maxLogLineLength is the longest log line read from the stream, longer lines are reported as errors.
+	maxLogLineLength = 1024 * 1024
+)
+
+// logTarget is a single container to stream logs from.
+type logTarget struct {
+	context   string
+	namespace string
+	pod       string
+	container string
+	// colour is index of the pod, so lines from the same pod share a colour.
+	colour int
+}
+
+type logOptions struct {
+	follow   bool
+	previous bool
+	// since limits logs to the last duration, zero means no limit.
+	since time.Duration
+	// tail is number of last lines from every container, zero means all lines.
+	tail int64
+}
+
+var logSinceOptions = []time.Duration{0, time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, 24 * time.Hour}
+
+var logTailOptions = []int64{100, 500, 1000, 0}
+
+func (o logOptions) podLogOptions(container string) *v1.PodLogOptions {
+	plo := &v1.PodLogOptions{
+		Container: container,
+		Follow:    o.follow,
+		Previous:  o.previous,
+	}
+	if o.since > 0 {
+		seconds := int64(o.since.Seconds())
+		plo.SinceSeconds = &seconds
+	}
+	if o.tail > 0 {
+		tail := o.tail
+		plo.TailLines = &tail
+	}
+	return plo
+}
+
+// logLine is a line read from container logs, err is set when stream could not be opened or read.
+type logLine struct {
+	target logTarget
+	text   string
+	err    error
+}
+
+// logStreamer is implemented by Client, it is not available when app is replaying a record file.
+type logStreamer interface {
+	streamLogs(ctx context.Context, targets []logTarget, opts logOptions, lineCh chan<- logLine)
+}
+
+// logTargets returns containers which logs are shown for the item, all containers of all pods for a pod group, all
+// pod containers for a pod and a single container for a container or its detail.
+func logTargets(item Item) (title string, targets []logTarget) {
+	switch i := item.(type) {
+	case *PodGroup:
+		for pIndex := range i.pods {
+			targets = append(targets, podLogTargets(&i.pods[pIndex], pIndex)...)
+		}
+		return i.name, targets
+	case *Pod:
+		return i.name, podLogTargets(i, 0)
+	case *Container:
+		return containerLogTarget(i)
+	case *ContainerDetail:
+		return containerLogTarget(i.container)
+	default:
+		return "", nil
+	}
+}
+
+// markedLogTargets returns all containers of marked pods, pods can be from different namespaces and contexts.
+func markedLogTargets(pods []*Pod) (title string, targets []logTarget) {
+	for pIndex, p := range pods {
+		targets = append(targets, podLogTargets(p, pIndex)...)
+	}
+	return fmt.Sprintf("%d marked pods", len(pods)), targets
+}
+
+func podLogTargets(p *Pod, colour int) []logTarget {
+	ns := p.podGroup.namespace
+	targets := make([]logTarget, 0, len(p.containers))
+	for cIndex := range p.containers {
+		targets = append(targets, logTarget{context: ns.context, namespace: ns.name, pod: p.name, container: p.containers[cIndex].name, colour: colour})
+	}
+	return targets
+}
+
+func containerLogTarget(c *Container) (title string, targets []logTarget) {
+	ns := c.pod.podGroup.namespace
+	return c.pod.name + "/" + c.name, []logTarget{{context: ns.context, namespace: ns.name, pod: c.pod.name, container: c.name}}
+}
+
+// streamLogs reads logs of every target concurrently and sends them line by line to lineCh, which is closed when
+// all streams are finished or ctx is cancelled.
+func (k8Client Client) streamLogs(ctx context.Context, targets []logTarget, opts logOptions, lineCh chan<- logLine) {
+	defer close(lineCh)
+
+	wg := sync.WaitGroup{}
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target logTarget) {
+			defer wg.Done()
+			send := func(line logLine) bool {
+				select {
+				case lineCh <- line:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			clientSet, ok := k8Client.k8ClientSets[target.context]
+			if !ok {
+				send(logLine{target: target, err: errors.New(fmt.Sprintf("no client for context %v", target.context))})
+				return
+			}
+			stream, err := clientSet.CoreV1().Pods(target.namespace).GetLogs(target.pod, opts.podLogOptions(target.container)).Stream(ctx)
+			if err != nil {
+				send(logLine{target: target, err: err})
+				return
+			}
+			defer stream.Close()
+
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineLength)
+			for scanner.Scan() {
+				if !send(logLine{target: target, text: strings.TrimRight(scanner.Text(), "\r")}) {
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				send(logLine{target: target, err: err})
+			}
+		}(target)
+	}
+	wg.Wait()
+}
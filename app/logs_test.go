@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLogTargets(t *testing.T) {
+	ns := &Namespace{name: "ns1", context: "context"}
+	pg := &PodGroup{name: "web", namespace: ns}
+	pg.pods = []Pod{
+		{name: "web-1", podGroup: pg, containers: []Container{{name: "app"}, {name: "proxy"}}},
+		{name: "web-2", podGroup: pg, containers: []Container{{name: "app"}}},
+	}
+	pg.pods[1].containers[0].pod = &pg.pods[1]
+
+	testTable := []struct {
+		name            string
+		item            Item
+		expectedTitle   string
+		expectedTargets []logTarget
+	}{
+		{
+			name:          "pod_group",
+			item:          pg,
+			expectedTitle: "web",
+			expectedTargets: []logTarget{
+				{context: "context", namespace: "ns1", pod: "web-1", container: "app", colour: 0},
+				{context: "context", namespace: "ns1", pod: "web-1", container: "proxy", colour: 0},
+				{context: "context", namespace: "ns1", pod: "web-2", container: "app", colour: 1},
+			},
+		},
+		{
+			name:          "pod",
+			item:          &pg.pods[0],
+			expectedTitle: "web-1",
+			expectedTargets: []logTarget{
+				{context: "context", namespace: "ns1", pod: "web-1", container: "app"},
+				{context: "context", namespace: "ns1", pod: "web-1", container: "proxy"},
+			},
+		},
+		{
+			name:            "container",
+			item:            &pg.pods[1].containers[0],
+			expectedTitle:   "web-2/app",
+			expectedTargets: []logTarget{{context: "context", namespace: "ns1", pod: "web-2", container: "app"}},
+		},
+		{
+			name: "namespace",
+			item: ns,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			title, targets := logTargets(tc.item)
+			if title != tc.expectedTitle {
+				t.Errorf("Invalid title. Want: %v, Got: %v", tc.expectedTitle, title)
+			}
+			if !reflect.DeepEqual(targets, tc.expectedTargets) {
+				t.Errorf("Invalid targets. Want: %v, Got: %v", tc.expectedTargets, targets)
+			}
+		})
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	client := Client{k8ClientSets: clientSetMap{"context": clientSet}}
+	targets := []logTarget{
+		{context: "context", namespace: "ns1", pod: "web-1", container: "app"},
+		{context: "context", namespace: "ns1", pod: "web-2", container: "app", colour: 1},
+	}
+	opts := logOptions{previous: true, since: 5 * time.Minute, tail: 100}
+
+	lineCh := make(chan logLine)
+	go client.streamLogs(context.Background(), targets, opts, lineCh)
+
+	pods := make([]string, 0)
+	for line := range lineCh {
+		if line.err != nil {
+			t.Fatalf("Unexpected error: %v", line.err)
+		}
+		if line.text != "fake logs" {
+			t.Errorf("Invalid line. Want: %v, Got: %v", "fake logs", line.text)
+		}
+		pods = append(pods, line.target.pod)
+	}
+	sort.Strings(pods)
+	if !reflect.DeepEqual(pods, []string{"web-1", "web-2"}) {
+		t.Errorf("Invalid pods. Want: %v, Got: %v", []string{"web-1", "web-2"}, pods)
+	}
+
+	for _, action := range clientSet.Actions() {
+		plo, ok := action.(k8stesting.GenericAction).GetValue().(*v1.PodLogOptions)
+		if !ok {
+			t.Fatalf("Invalid action value: %v", action)
+		}
+		if plo.Container != "app" || !plo.Previous || *plo.SinceSeconds != 300 || *plo.TailLines != 100 || plo.Follow {
+			t.Errorf("Invalid log options: %+v", plo)
+		}
+	}
+
+	lineCh = make(chan logLine, 1)
+	client.streamLogs(context.Background(), []logTarget{{context: "unknown", namespace: "ns1", pod: "web-1"}}, opts, lineCh)
+	if line := <-lineCh; line.err == nil {
+		t.Errorf("Expected error for unknown context")
+	}
+}
+
+func TestLogFramePause(t *testing.T) {
+	lf := NewLogFrame(80, 5)
+	lf.targets = []logTarget{{pod: "web-1", container: "app"}, {pod: "web-2", container: "app", colour: 1}}
+
+	lines := func(texts ...string) []logLine {
+		result := make([]logLine, 0, len(texts))
+		for _, text := range texts {
+			result = append(result, logLine{target: lf.targets[0], text: text})
+		}
+		return result
+	}
+
+	lf.appendLines(lines("1", "2", "3", "4"))
+	if lf.topRow != 2 {
+		t.Errorf("View should follow the end. Want top row: %v, Got: %v", 2, lf.topRow)
+	}
+	if lf.lines[0].prefix != "web-1 app " {
+		t.Errorf("Invalid prefix. Want: %v, Got: %v", "web-1 app ", lf.lines[0].prefix)
+	}
+
+	lf.togglePause()
+	lf.appendLines(lines("5", "6"))
+	if len(lf.lines) != 4 || len(lf.pending) != 2 {
+		t.Errorf("Lines should be pending while paused. Got lines: %v, pending: %v", len(lf.lines), len(lf.pending))
+	}
+	lf.togglePause()
+	if len(lf.lines) != 6 || lf.topRow != 4 {
+		t.Errorf("Pending lines should be added on resume. Got lines: %v, top row: %v", len(lf.lines), lf.topRow)
+	}
+
+	lf.scroll(-3)
+	lf.appendLines(lines("7"))
+	if lf.topRow != 1 {
+		t.Errorf("View should stay in place when scrolled up. Want top row: %v, Got: %v", 1, lf.topRow)
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+)
+
+// MarkSymbol is drawn in front of marked pod names.
+const MarkSymbol = "*"
+
+// podKey identifies a pod across updates, as pods are recreated every time their namespace is updated.
+type podKey struct {
+	context   string
+	namespace string
+	name      string
+}
+
+func keyOfPod(p *Pod) podKey {
+	return podKey{context: p.podGroup.namespace.context, namespace: p.podGroup.namespace.name, name: p.name}
+}
+
+// podOfItem returns pod of a pod, container or container detail item, nil for other items.
+func podOfItem(item Item) *Pod {
+	switch i := item.(type) {
+	case *Pod:
+		return i
+	case *Container:
+		return i.pod
+	case *ContainerDetail:
+		return i.container.pod
+	default:
+		return nil
+	}
+}
+
+func (f *InfoFrame) isMarked(p *Pod) bool {
+	if len(f.marked) == 0 {
+		return false
+	}
+	_, ok := f.marked[keyOfPod(p)]
+	return ok
+}
+
+func (f *InfoFrame) setMarked(p *Pod, marked bool) {
+	if f.marked == nil {
+		f.marked = make(map[podKey]struct{})
+	}
+	if marked {
+		f.marked[keyOfPod(p)] = struct{}{}
+	} else {
+		delete(f.marked, keyOfPod(p))
+	}
+}
+
+// markedPods returns currently listed pods which are marked, in the order they are displayed. Marks of pods which are
+// gone are kept, so a pod is marked again if it comes back with the same name.
+func (f *InfoFrame) markedPods() []*Pod {
+	pods := make([]*Pod, 0)
+	if len(f.marked) == 0 {
+		return pods
+	}
+	for nsIndex := range f.nsItems {
+		for _, pg := range f.nsItems[nsIndex].deployments {
+			for pIndex := range pg.pods {
+				if f.isMarked(&pg.pods[pIndex]) {
+					pods = append(pods, &pg.pods[pIndex])
+				}
+			}
+		}
+	}
+	return pods
+}
+
+// toggleMark marks or unmarks the pod under cursor and moves cursor to the next item, so pods can be marked one after
+// another.
+func (f *InfoFrame) toggleMark(s tcell.Screen) bool {
+	if len(f.positions) == 0 {
+		return false
+	}
+	p := podOfItem(f.positions[f.cursorFullPosition()])
+	if p == nil {
+		return false
+	}
+	f.setMarked(p, !f.isMarked(p))
+	f.moveCursor(s, 1)
+	f.refresh(s)
+	return true
+}
+
+// toggleMarkAll marks all pods of the namespace under cursor or of the pod group of the item under cursor. When all of
+// them are already marked they are unmarked instead.
+func (f *InfoFrame) toggleMarkAll(s tcell.Screen) bool {
+	if len(f.positions) == 0 {
+		return false
+	}
+	pods := make([]*Pod, 0)
+	switch i := f.positions[f.cursorFullPosition()].(type) {
+	case *Namespace:
+		for _, pg := range i.deployments {
+			pods = appendGroupPods(pods, pg)
+		}
+	case *PodGroup:
+		pods = appendGroupPods(pods, i)
+	default:
+		if p := podOfItem(i); p != nil {
+			pods = appendGroupPods(pods, p.podGroup)
+		}
+	}
+	if len(pods) == 0 {
+		return false
+	}
+
+	allMarked := true
+	for _, p := range pods {
+		allMarked = allMarked && f.isMarked(p)
+	}
+	for _, p := range pods {
+		f.setMarked(p, !allMarked)
+	}
+	f.refresh(s)
+	return true
+}
+
+func appendGroupPods(pods []*Pod, pg *PodGroup) []*Pod {
+	for pIndex := range pg.pods {
+		pods = append(pods, &pg.pods[pIndex])
+	}
+	return pods
+}
+
+// clearMarks unmarks all pods, returns false when no listed pods were marked.
+func (f *InfoFrame) clearMarks(s tcell.Screen) bool {
+	if len(f.markedPods()) == 0 {
+		return false
+	}
+	f.marked = nil
+	f.refresh(s)
+	return true
+}
+
+// markInfo describes marked pods for the header, empty when there are none.
+func (f *InfoFrame) markInfo() string {
+	count := len(f.markedPods())
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[marked: %d]", count)
+}
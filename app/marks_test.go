@@ -0,0 +1,163 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"reflect"
+	"testing"
+)
+
+// fakeMarkNamespaces returns two namespaces, the first one with groups web (web-1, web-2) and api (api-1), the second
+// one with group db (db-0). Namespaces and groups are expanded.
+func fakeMarkNamespaces() []Namespace {
+	namespaces := []Namespace{
+		{name: "ns1", context: "context", isExpanded: true},
+		{name: "ns2", context: "context", isExpanded: true},
+	}
+	groups := map[int][]*PodGroup{
+		0: {{name: "web", isExpanded: true}, {name: "api", isExpanded: true}},
+		1: {{name: "db", isExpanded: true}},
+	}
+	pods := map[string][]string{"web": {"web-1", "web-2"}, "api": {"api-1"}, "db": {"db-0"}}
+	for nsIndex := range namespaces {
+		for _, pg := range groups[nsIndex] {
+			pg.namespace = &namespaces[nsIndex]
+			for _, name := range pods[pg.name] {
+				pg.pods = append(pg.pods, Pod{name: name, podGroup: pg, containers: []Container{{name: "app"}}})
+			}
+			for pIndex := range pg.pods {
+				pg.pods[pIndex].containers[0].pod = &pg.pods[pIndex]
+			}
+		}
+		namespaces[nsIndex].deployments = groups[nsIndex]
+	}
+	return namespaces
+}
+
+func markedPodNames(f *InfoFrame) []string {
+	names := make([]string, 0)
+	for _, p := range f.markedPods() {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+func TestToggleMark(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	frame := InfoFrame{height: 20, nsItems: fakeMarkNamespaces()}
+	frame.updatePositions()
+
+	// Positions: ns1, web, web-1, web-2, api, api-1, ns2, db, db-0
+	frame.moveCursor(screen, 2)
+	if !frame.toggleMark(screen) {
+		t.Fatalf("Pod should be marked")
+	}
+	if frame.cursorY != 3 {
+		t.Errorf("Cursor should move to the next item. Want: 3, Got: %v", frame.cursorY)
+	}
+	frame.moveCursor(screen, 5)
+	frame.toggleMark(screen)
+	if got := markedPodNames(&frame); !reflect.DeepEqual(got, []string{"web-1", "db-0"}) {
+		t.Errorf("Invalid marked pods. Want: %v, Got: %v", []string{"web-1", "db-0"}, got)
+	}
+
+	// Marks are kept when pods are recreated by an update.
+	frame.nsItems = fakeMarkNamespaces()
+	frame.updatePositions()
+	if got := markedPodNames(&frame); !reflect.DeepEqual(got, []string{"web-1", "db-0"}) {
+		t.Errorf("Marks should be kept after update. Want: %v, Got: %v", []string{"web-1", "db-0"}, got)
+	}
+
+	frame.moveCursor(screen, -20)
+	if frame.toggleMark(screen) {
+		t.Errorf("Namespace should not be marked")
+	}
+	if !frame.clearMarks(screen) || len(frame.markedPods()) != 0 {
+		t.Errorf("Marks should be cleared")
+	}
+	if frame.clearMarks(screen) {
+		t.Errorf("Clearing without marks should return false")
+	}
+}
+
+func TestToggleMarkAll(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	testTable := []struct {
+		name     string
+		cursor   int
+		marked   []string
+		expected []string
+	}{
+		{name: "namespace", cursor: 0, expected: []string{"web-1", "web-2", "api-1"}},
+		{name: "group", cursor: 1, expected: []string{"web-1", "web-2"}},
+		{name: "pod_marks_its_group", cursor: 5, expected: []string{"api-1"}},
+		{name: "partially_marked_group_is_marked", cursor: 1, marked: []string{"web-2"}, expected: []string{"web-1", "web-2"}},
+		{name: "marked_group_is_unmarked", cursor: 3, marked: []string{"web-1", "web-2", "db-0"}, expected: []string{"db-0"}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			frame := InfoFrame{height: 20, nsItems: fakeMarkNamespaces()}
+			frame.updatePositions()
+			for nsIndex := range frame.nsItems {
+				for _, pg := range frame.nsItems[nsIndex].deployments {
+					for pIndex := range pg.pods {
+						for _, name := range tc.marked {
+							if pg.pods[pIndex].name == name {
+								frame.setMarked(&pg.pods[pIndex], true)
+							}
+						}
+					}
+				}
+			}
+
+			frame.moveCursor(screen, tc.cursor)
+			frame.toggleMarkAll(screen)
+			if got := markedPodNames(&frame); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Invalid marked pods. Want: %v, Got: %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAssembleMarkedCommands(t *testing.T) {
+	namespaces := fakeMarkNamespaces()
+	web := namespaces[0].deployments[0]
+	db := namespaces[1].deployments[0]
+	db.pods[0].containers = append(db.pods[0].containers, Container{name: "exporter"})
+	pods := []*Pod{&web.pods[0], &db.pods[0]}
+
+	if got := containerNamesFromPods(pods); !reflect.DeepEqual(got, []string{"app", "exporter"}) {
+		t.Errorf("Invalid container names. Want: %v, Got: %v", []string{"app", "exporter"}, got)
+	}
+
+	tmpl := "kubectl --context %v -n %v logs %v -c %v"
+	expected := []string{
+		"kubectl --context context -n ns1 logs web-1 -c app",
+		"kubectl --context context -n ns2 logs db-0 -c app",
+	}
+	if got := assembleMarkedCommands(tmpl, "app", pods); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Invalid commands. Want: %v, Got: %v", expected, got)
+	}
+	// Pods without the container are skipped.
+	expected = []string{"kubectl --context context -n ns2 logs db-0 -c exporter"}
+	if got := assembleMarkedCommands(tmpl, "exporter", pods); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Invalid commands. Want: %v, Got: %v", expected, got)
+	}
+}
+
+func TestMarkedLogTargets(t *testing.T) {
+	namespaces := fakeMarkNamespaces()
+	pods := []*Pod{&namespaces[0].deployments[0].pods[1], &namespaces[1].deployments[0].pods[0]}
+
+	title, targets := markedLogTargets(pods)
+	if title != "2 marked pods" {
+		t.Errorf("Invalid title. Want: %v, Got: %v", "2 marked pods", title)
+	}
+	expected := []logTarget{
+		{context: "context", namespace: "ns1", pod: "web-2", container: "app", colour: 0},
+		{context: "context", namespace: "ns2", pod: "db-0", container: "app", colour: 1},
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("Invalid targets. Want: %v, Got: %v", expected, targets)
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	v1 "k8s.io/api/core/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// containerUsage is a container resource usage from metrics.k8s.io, cpu in millicores and memory in bytes. Fields are
+// exported so it can be recorded together with pod lists.
+type containerUsage struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+// containerResources are requests and limits from container spec, zero means not set.
+type containerResources struct {
+	cpuRequest    int64
+	cpuLimit      int64
+	memoryRequest int64
+	memoryLimit   int64
+}
+
+func toContainerResources(c *v1.Container) containerResources {
+	return containerResources{
+		cpuRequest:    c.Resources.Requests.Cpu().MilliValue(),
+		cpuLimit:      c.Resources.Limits.Cpu().MilliValue(),
+		memoryRequest: c.Resources.Requests.Memory().Value(),
+		memoryLimit:   c.Resources.Limits.Memory().Value(),
+	}
+}
+
+// toPodUsages converts pod metrics into usage per pod name and container name.
+func toPodUsages(podMetrics []metricsv1beta1.PodMetrics) map[string]map[string]containerUsage {
+	result := make(map[string]map[string]containerUsage, len(podMetrics))
+	for pIndex := range podMetrics {
+		containers := make(map[string]containerUsage, len(podMetrics[pIndex].Containers))
+		for _, c := range podMetrics[pIndex].Containers {
+			containers[c.Name] = containerUsage{
+				CPU:    c.Usage.Cpu().MilliValue(),
+				Memory: c.Usage.Memory().Value(),
+			}
+		}
+		result[podMetrics[pIndex].Name] = containers
+	}
+	return result
+}
+
+// metricsCells returns cpu and memory column values for a single container.
+func (c *Container) metricsCells() (cpu, memory string) {
+	if c.pod.podGroup.namespace.metricsUnavailable {
+		return "n/a", "n/a"
+	}
+	if c.usage == nil {
+		return "-", "-"
+	}
+	cpu = formatUsage(c.usage.CPU, c.resources.cpuRequest, c.resources.cpuLimit, formatCPU)
+	memory = formatUsage(c.usage.Memory, c.resources.memoryRequest, c.resources.memoryLimit, formatMemory)
+	return cpu, memory
+}
+
+// metricsCells returns cpu and memory column values for a pod as a sum of all regular containers, requests and limits
+// are used only when they are set for every container.
+func (p *Pod) metricsCells() (cpu, memory string) {
+	if p.podGroup.namespace.metricsUnavailable {
+		return "n/a", "n/a"
+	}
+
+	var usage containerUsage
+	total := containerResources{}
+	hasUsage := false
+	allCPURequests, allCPULimits, allMemoryRequests, allMemoryLimits := true, true, true, true
+	for index := range p.containers {
+		c := &p.containers[index]
+		if c.kind != ContainerRegular {
+			continue
+		}
+		if c.usage != nil {
+			hasUsage = true
+			usage.CPU += c.usage.CPU
+			usage.Memory += c.usage.Memory
+		}
+		total.cpuRequest += c.resources.cpuRequest
+		total.cpuLimit += c.resources.cpuLimit
+		total.memoryRequest += c.resources.memoryRequest
+		total.memoryLimit += c.resources.memoryLimit
+		allCPURequests = allCPURequests && c.resources.cpuRequest > 0
+		allCPULimits = allCPULimits && c.resources.cpuLimit > 0
+		allMemoryRequests = allMemoryRequests && c.resources.memoryRequest > 0
+		allMemoryLimits = allMemoryLimits && c.resources.memoryLimit > 0
+	}
+	if !hasUsage {
+		return "-", "-"
+	}
+	if !allCPURequests {
+		total.cpuRequest = 0
+	}
+	if !allCPULimits {
+		total.cpuLimit = 0
+	}
+	if !allMemoryRequests {
+		total.memoryRequest = 0
+	}
+	if !allMemoryLimits {
+		total.memoryLimit = 0
+	}
+
+	cpu = formatUsage(usage.CPU, total.cpuRequest, total.cpuLimit, formatCPU)
+	memory = formatUsage(usage.Memory, total.memoryRequest, total.memoryLimit, formatMemory)
+	return cpu, memory
+}
+
+// formatUsage returns usage followed by percentage of request and limit, '-' is used when they are not set.
+// For example '250m 50%/25%'.
+func formatUsage(usage, request, limit int64, format func(int64) string) string {
+	if request == 0 && limit == 0 {
+		return format(usage)
+	}
+	return fmt.Sprintf("%v %v/%v", format(usage), percentOf(usage, request), percentOf(usage, limit))
+}
+
+func percentOf(value, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", value*100/total)
+}
+
+func formatCPU(milliCores int64) string {
+	return fmt.Sprintf("%dm", milliCores)
+}
+
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
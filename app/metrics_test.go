@@ -0,0 +1,60 @@
+package app
+
+import "testing"
+
+func TestPodMetricsCells(t *testing.T) {
+	ns := Namespace{name: "ns", context: "context"}
+	pg := PodGroup{name: "web", namespace: &ns}
+	pod := Pod{name: "web-1", podGroup: &pg}
+	pod.containers = []Container{
+		{
+			name:      "app",
+			resources: containerResources{cpuRequest: 200, cpuLimit: 1000, memoryRequest: 256 << 20},
+			usage:     &containerUsage{CPU: 100, Memory: 128 << 20},
+			pod:       &pod,
+		},
+		{
+			name:      "sidecar",
+			resources: containerResources{cpuRequest: 50, cpuLimit: 100},
+			usage:     &containerUsage{CPU: 50, Memory: 64 << 20},
+			pod:       &pod,
+		},
+	}
+
+	testTable := []struct {
+		name           string
+		cells          func() (string, string)
+		expectedCPU    string
+		expectedMemory string
+	}{
+		{
+			name:           "pod_sum_memory_request_missing_in_sidecar",
+			cells:          pod.metricsCells,
+			expectedCPU:    "150m 60%/13%",
+			expectedMemory: "192Mi",
+		},
+		{
+			name:           "container_without_memory_limit",
+			cells:          pod.containers[0].metricsCells,
+			expectedCPU:    "100m 50%/10%",
+			expectedMemory: "128Mi 50%/-",
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu, memory := tc.cells()
+			if cpu != tc.expectedCPU {
+				t.Errorf("Invalid CPU. Want: %v, Got: %v", tc.expectedCPU, cpu)
+			}
+			if memory != tc.expectedMemory {
+				t.Errorf("Invalid MEMORY. Want: %v, Got: %v", tc.expectedMemory, memory)
+			}
+		})
+	}
+
+	ns.metricsUnavailable = true
+	if cpu, memory := pod.metricsCells(); cpu != "n/a" || memory != "n/a" {
+		t.Errorf("Metrics should be n/a when unavailable, Got: %v %v", cpu, memory)
+	}
+}
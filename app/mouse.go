@@ -0,0 +1,167 @@
+package app
+
+import "github.com/gdamore/tcell/v2"
+
+const (
+	// MouseWheelLines is number of lines scrolled by a single wheel step.
+	MouseWheelLines = 3
+)
+
+// clickButtons are buttons which make clicks, wheel events are reported as buttons too, but they are never held.
+const clickButtons = tcell.Button1 | tcell.Button2 | tcell.Button3
+
+// clickTracker tells new clicks from drags, as terminals report held buttons with every mouse motion.
+type clickTracker struct {
+	held tcell.ButtonMask
+}
+
+// pressed returns true when no click button was held before the event, it records buttons held after it.
+func (c *clickTracker) pressed(ev *tcell.EventMouse) bool {
+	pressed := c.held == tcell.ButtonNone
+	c.held = ev.Buttons() & clickButtons
+	return pressed
+}
+
+// positionAt returns index in positions of the item displayed on screen row y, -1 if there is none.
+func (f *InfoFrame) positionAt(y int) int {
+	if y < f.y || y >= f.y+f.height {
+		return -1
+	}
+	pos := y - f.y + f.scrollYOffset
+	if pos >= len(f.positions) {
+		return -1
+	}
+	return pos
+}
+
+// rowMarkerWidth returns width of the area in front of the item name, where the cursor is shown. Clicking there
+// expands or collapses the item.
+func rowMarkerWidth(item Item) int {
+	switch i := item.(type) {
+	case *Namespace:
+		return NamespaceXOffset + 1
+	case *PodGroup:
+		return PodGroupXOffset + 1
+	case *Pod:
+		return PodXOffset + 1
+	case *Container:
+		return ContainerXOffset + 1
+	case *Events:
+		return EventsXOffset + 1
+	case *Event:
+		return i.level*2 + 1
+	default:
+		return 1
+	}
+}
+
+// clickAt moves cursor to the item on the clicked row, clicking on its row marker expands or collapses it.
+func (f *InfoFrame) clickAt(s tcell.Screen, x, y int) {
+	pos := f.positionAt(y)
+	if pos < 0 {
+		return
+	}
+	f.moveCursor(s, pos-f.cursorFullPosition())
+	if x-f.x+f.xOffset < rowMarkerWidth(f.positions[pos]) {
+		item := f.positions[pos]
+		item.Expanded(!item.IsExpanded())
+		f.refresh(s)
+	}
+}
+
+// scrollLines scrolls the view by n lines keeping the cursor on the same item while it is visible.
+func (f *InfoFrame) scrollLines(s tcell.Screen, n int) {
+	maxOffset := len(f.positions) - f.height
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	newOffset := f.scrollYOffset + n
+	if newOffset > maxOffset {
+		newOffset = maxOffset
+	}
+	if newOffset < 0 {
+		newOffset = 0
+	}
+
+	cursorPos := f.cursorFullPosition() - newOffset
+	if cursorPos < 0 {
+		cursorPos = 0
+	}
+	if cursorPos > f.height-1 {
+		cursorPos = f.height - 1
+	}
+	f.scrollYOffset = newOffset
+	f.cursorY = cursorPos
+	f.refresh(s)
+}
+
+// itemAt returns index of the popup item on screen position, -1 if there is none.
+func (pf *PopupFrame) itemAt(x, y int) int {
+	index := y - pf.y - PopupItemYOffset
+	if x <= pf.x || x >= pf.x+pf.width || index < 0 || index >= len(pf.items) {
+		return -1
+	}
+	return index
+}
+
+// handleMouse handles wheel scrolling and left button presses, pressed is true only for the event when button went
+// down, so holding the button does not repeat clicks.
+func (gui *Gui) handleMouse(ev *tcell.EventMouse, pressed bool) {
+	buttons := ev.Buttons()
+	x, y := ev.Position()
+	wheel := 0
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		wheel = -MouseWheelLines
+	case buttons&tcell.WheelDown != 0:
+		wheel = MouseWheelLines
+	case buttons&(tcell.WheelLeft|tcell.WheelRight) != 0:
+		if gui.overlay() == nil && !gui.popupFrame.visible {
+			step := HorizontalScrollStep
+			if buttons&tcell.WheelLeft != 0 {
+				step = -step
+			}
+			gui.scrollColumns(step)
+		}
+		return
+	case buttons&tcell.Button1 == 0 || !pressed:
+		return
+	}
+
+	switch {
+	case gui.overlay() != nil:
+		if wheel != 0 {
+			gui.overlay().scrollView(gui.s, wheel)
+		}
+	case gui.popupFrame.visible:
+		gui.handlePopupMouse(x, y, wheel)
+	case wheel != 0:
+		gui.mainFrame.scrollLines(gui.s, wheel)
+		gui.updateStatusFrame()
+		gui.s.Show()
+	default:
+		gui.mainFrame.clickAt(gui.s, x, y)
+		gui.updateStatusFrame()
+		gui.s.Show()
+	}
+}
+
+// handlePopupMouse moves popup cursor with the wheel, click on an item selects it, click outside closes the popup.
+func (gui *Gui) handlePopupMouse(x, y, wheel int) {
+	switch {
+	case wheel < 0:
+		gui.popupFrame.moveCursorUp(gui.s)
+		gui.s.Show()
+	case wheel > 0:
+		gui.popupFrame.moveCursorDown(gui.s)
+		gui.s.Show()
+	default:
+		index := gui.popupFrame.itemAt(x, y)
+		if index < 0 {
+			gui.hidePopupFrame()
+			return
+		}
+		gui.popupFrame.cursorYPos = index
+		gui.handleEnterKey()
+	}
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"testing"
+)
+
+func TestClickAt(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	testTable := []struct {
+		name             string
+		x, y             int
+		expectedCurY     int
+		expectedExpanded bool
+	}{
+		{name: "click_name_moves_cursor", x: 5, y: 3, expectedCurY: 3, expectedExpanded: false},
+		{name: "click_marker_expands", x: 0, y: 3, expectedCurY: 3, expectedExpanded: true},
+		{name: "click_below_positions_is_ignored", x: 0, y: 8, expectedCurY: 0, expectedExpanded: false},
+		{name: "click_above_frame_is_ignored", x: 0, y: -1, expectedCurY: 0, expectedExpanded: false},
+	}
+
+	for index, tc := range testTable {
+		t.Run(fmt.Sprintf("%v %v", index, tc.name), func(t *testing.T) {
+			frame := InfoFrame{height: 10, nsItems: fakeNamespaces(5)}
+			frame.nsItems[3].deployments = []*PodGroup{{name: "web"}}
+			frame.updatePositions()
+
+			frame.clickAt(screen, tc.x, tc.y)
+			if frame.cursorY != tc.expectedCurY {
+				t.Errorf("Invalid Cursor Y position. Want: %v, Got: %v", tc.expectedCurY, frame.cursorY)
+			}
+			if frame.nsItems[3].IsExpanded() != tc.expectedExpanded {
+				t.Errorf("Invalid expanded state. Want: %v, Got: %v", tc.expectedExpanded, frame.nsItems[3].IsExpanded())
+			}
+		})
+	}
+}
+
+func TestScrollLines(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	testTable := []struct {
+		name           string
+		frame          InfoFrame
+		scrollBy       int
+		expectedCurY   int
+		expectedOffset int
+	}{
+		{
+			name:           "scroll_down_keeps_cursor_on_item",
+			frame:          InfoFrame{height: 10, cursorY: 5, nsItems: fakeNamespaces(20)},
+			scrollBy:       3,
+			expectedCurY:   2,
+			expectedOffset: 3,
+		},
+		{
+			name:           "scroll_down_cursor_stays_on_screen",
+			frame:          InfoFrame{height: 10, cursorY: 1, nsItems: fakeNamespaces(20)},
+			scrollBy:       3,
+			expectedCurY:   0,
+			expectedOffset: 3,
+		},
+		{
+			name:           "scroll_down_stops_at_the_end",
+			frame:          InfoFrame{height: 10, cursorY: 9, scrollYOffset: 8, nsItems: fakeNamespaces(20)},
+			scrollBy:       3,
+			expectedCurY:   7,
+			expectedOffset: 10,
+		},
+		{
+			name:           "scroll_up_cursor_stays_on_screen",
+			frame:          InfoFrame{height: 10, cursorY: 8, scrollYOffset: 5, nsItems: fakeNamespaces(20)},
+			scrollBy:       -3,
+			expectedCurY:   9,
+			expectedOffset: 2,
+		},
+		{
+			name:           "scroll_up_stops_at_the_top",
+			frame:          InfoFrame{height: 10, cursorY: 0, scrollYOffset: 1, nsItems: fakeNamespaces(20)},
+			scrollBy:       -3,
+			expectedCurY:   1,
+			expectedOffset: 0,
+		},
+		{
+			name:           "no_scroll_when_positions_fit",
+			frame:          InfoFrame{height: 10, cursorY: 2, nsItems: fakeNamespaces(5)},
+			scrollBy:       3,
+			expectedCurY:   2,
+			expectedOffset: 0,
+		},
+	}
+
+	for index := range testTable {
+		tc := &testTable[index]
+		t.Run(fmt.Sprintf("%v %v", index, tc.name), func(t *testing.T) {
+			tc.frame.updatePositions()
+			tc.frame.scrollLines(screen, tc.scrollBy)
+			if tc.frame.cursorY != tc.expectedCurY {
+				t.Errorf("Invalid Cursor Y position. Want: %v, Got: %v", tc.expectedCurY, tc.frame.cursorY)
+			}
+			if tc.frame.scrollYOffset != tc.expectedOffset {
+				t.Errorf("Invalid Offset. Want: %v, Got: %v", tc.expectedOffset, tc.frame.scrollYOffset)
+			}
+		})
+	}
+}
+
+func TestPopupItemAt(t *testing.T) {
+	popup := PopupFrame{x: 10, y: 5, width: 20, height: 6, items: []string{"a", "b", "c"}}
+	testTable := []struct {
+		name     string
+		x, y     int
+		expected int
+	}{
+		{name: "first_item", x: 12, y: 7, expected: 0},
+		{name: "last_item", x: 12, y: 9, expected: 2},
+		{name: "title_row", x: 12, y: 6, expected: -1},
+		{name: "below_items", x: 12, y: 10, expected: -1},
+		{name: "left_border", x: 10, y: 7, expected: -1},
+		{name: "outside", x: 40, y: 7, expected: -1},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := popup.itemAt(tc.x, tc.y); got != tc.expected {
+				t.Errorf("Invalid item index. Want: %v, Got: %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClickTracker(t *testing.T) {
+	clicks := clickTracker{}
+	testTable := []struct {
+		name     string
+		buttons  tcell.ButtonMask
+		expected bool
+	}{
+		{name: "wheel", buttons: tcell.WheelDown, expected: true},
+		{name: "click_after_wheel", buttons: tcell.Button1, expected: true},
+		{name: "drag", buttons: tcell.Button1, expected: false},
+		{name: "release", buttons: tcell.ButtonNone, expected: false},
+		{name: "wheel_after_release", buttons: tcell.WheelUp, expected: true},
+		{name: "second_click", buttons: tcell.Button1, expected: true},
+	}
+
+	for _, tc := range testTable {
+		if got := clicks.pressed(tcell.NewEventMouse(5, 5, tc.buttons, tcell.ModNone)); got != tc.expected {
+			t.Errorf("%v: invalid pressed. Want: %v, Got: %v", tc.name, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"strings"
+)
+
+const (
+	KindJob     = "Job"
+	KindCronJob = "CronJob"
+)
+
+// podOwner is the top level controller of a pod, for example Deployment instead of its ReplicaSet. Fields are
+// exported so it can be recorded together with pod lists.
+type podOwner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// ownerLookup is a cached result of following owner reference of an intermediate controller (ReplicaSet or Job).
+type ownerLookup struct {
+	parent *metav1.OwnerReference
+	err    error
+}
+
+// podOwnerEntry is a resolved owner of a pod together with controllerKey of the pod controller it was resolved from.
+type podOwnerEntry struct {
+	ref   string
+	owner podOwner
+}
+
+// ownerResolver follows ReplicaSet -> Deployment and Job -> CronJob owner references for pods in a single namespace.
+// Owners are resolved only for pods which are new or whose controller changed, every ReplicaSet or Job is fetched only
+// once while there are pods referencing it and failed lookups are cached as well, so watch events of known pods do not
+// make any requests.
+type ownerResolver struct {
+	clientSet kubernetes.Interface
+	namespace string
+	cache     map[string]ownerLookup
+	// pods are resolved owners keyed by pod name.
+	pods map[string]podOwnerEntry
+}
+
+func newOwnerResolver(clientSet kubernetes.Interface, namespace string) *ownerResolver {
+	return &ownerResolver{
+		clientSet: clientSet,
+		namespace: namespace,
+		cache:     make(map[string]ownerLookup),
+		pods:      make(map[string]podOwnerEntry),
+	}
+}
+
+// resolve returns top level owners keyed by pod name, pods without controller are not included.
+func (r *ownerResolver) resolve(ctx context.Context, pods []v1.Pod) map[string]podOwner {
+	owners := make(map[string]podOwner, len(pods))
+	used := make(map[string]struct{})
+	listed := make(map[string]struct{}, len(pods))
+	for index := range pods {
+		listed[pods[index].Name] = struct{}{}
+		ref := controllerRef(pods[index].OwnerReferences)
+		if ref == nil {
+			continue
+		}
+		if ref.Kind != KindReplicaSet && ref.Kind != KindJob {
+			owners[pods[index].Name] = podOwner{Kind: ref.Kind, Name: ref.Name}
+			continue
+		}
+
+		key := controllerKey(ref.Kind, ref.Name)
+		used[key] = struct{}{}
+		if entry, ok := r.pods[pods[index].Name]; ok && entry.ref == key {
+			owners[pods[index].Name] = entry.owner
+			continue
+		}
+		lookup, ok := r.cache[key]
+		if !ok {
+			lookup = r.lookup(ctx, ref)
+			if ctx.Err() != nil {
+				// Do not cache lookups interrupted by shutdown.
+				return owners
+			}
+			r.cache[key] = lookup
+		}
+		switch {
+		case lookup.err != nil:
+			owners[pods[index].Name] = fallbackOwner(&pods[index])
+		case lookup.parent != nil:
+			owners[pods[index].Name] = podOwner{Kind: lookup.parent.Kind, Name: lookup.parent.Name}
+		default:
+			owners[pods[index].Name] = podOwner{Kind: ref.Kind, Name: ref.Name}
+		}
+		r.pods[pods[index].Name] = podOwnerEntry{ref: key, owner: owners[pods[index].Name]}
+	}
+
+	for key := range r.cache {
+		if _, ok := used[key]; !ok {
+			delete(r.cache, key)
+		}
+	}
+	for name := range r.pods {
+		if _, ok := listed[name]; !ok {
+			delete(r.pods, name)
+		}
+	}
+	return owners
+}
+
+func (r *ownerResolver) lookup(ctx context.Context, ref *metav1.OwnerReference) ownerLookup {
+	var refs []metav1.OwnerReference
+	switch ref.Kind {
+	case KindReplicaSet:
+		rs, err := r.clientSet.AppsV1().ReplicaSets(r.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return ownerLookup{err: err}
+		}
+		refs = rs.OwnerReferences
+	case KindJob:
+		job, err := r.clientSet.BatchV1().Jobs(r.namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return ownerLookup{err: err}
+		}
+		refs = job.OwnerReferences
+	}
+	return ownerLookup{parent: controllerRef(refs)}
+}
+
+// fallbackOwner is used when owner chain can not be followed, Deployment name is derived from ReplicaSet name using
+// pod-template-hash label, everything else is returned as referenced by the pod.
+func fallbackOwner(pod *v1.Pod) podOwner {
+	ref := controllerRef(pod.OwnerReferences)
+	if ref == nil {
+		return podOwner{}
+	}
+	if hash, ok := pod.Labels["pod-template-hash"]; ok && ref.Kind == KindReplicaSet && strings.HasSuffix(ref.Name, "-"+hash) {
+		return podOwner{Kind: KindDeployment, Name: strings.TrimSuffix(ref.Name, "-"+hash)}
+	}
+	return podOwner{Kind: ref.Kind, Name: ref.Name}
+}
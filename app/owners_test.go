@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"testing"
+)
+
+func TestOwnerResolverResolve(t *testing.T) {
+	isController := true
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+	clientSet := fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-5d9f8c7b6", Namespace: "ns", OwnerReferences: controlledBy(KindDeployment, "web"),
+		}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "ns"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name: "backup-1612345600", Namespace: "ns", OwnerReferences: controlledBy(KindCronJob, "backup"),
+		}},
+	)
+	gets := 0
+	clientSet.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-5d9f8c7b6-abcde", OwnerReferences: controlledBy(KindReplicaSet, "web-5d9f8c7b6")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-5d9f8c7b6-fghij", OwnerReferences: controlledBy(KindReplicaSet, "web-5d9f8c7b6")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "standalone-xyz", OwnerReferences: controlledBy(KindReplicaSet, "standalone")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "redis-master-0", OwnerReferences: controlledBy(KindStatefulSet, "redis-master")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "agent-xyz", OwnerReferences: controlledBy(KindDaemonSet, "agent")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "backup-1612345600-abc", OwnerReferences: controlledBy(KindJob, "backup-1612345600")}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-6b7c8d-klmno",
+			Labels:          map[string]string{"pod-template-hash": "6b7c8d"},
+			OwnerReferences: controlledBy(KindReplicaSet, "api-6b7c8d"),
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bare"}},
+	}
+
+	expected := map[string]podOwner{
+		"web-5d9f8c7b6-abcde":   {Kind: KindDeployment, Name: "web"},
+		"web-5d9f8c7b6-fghij":   {Kind: KindDeployment, Name: "web"},
+		"standalone-xyz":        {Kind: KindReplicaSet, Name: "standalone"},
+		"redis-master-0":        {Kind: KindStatefulSet, Name: "redis-master"},
+		"agent-xyz":             {Kind: KindDaemonSet, Name: "agent"},
+		"backup-1612345600-abc": {Kind: KindCronJob, Name: "backup"},
+		// ReplicaSet not found, Deployment is derived from pod-template-hash.
+		"api-6b7c8d-klmno": {Kind: KindDeployment, Name: "api"},
+	}
+
+	resolver := newOwnerResolver(clientSet, "ns")
+	for run := 0; run < 2; run++ {
+		owners := resolver.resolve(context.Background(), pods)
+		if len(owners) != len(expected) {
+			t.Errorf("Invalid number of owners. Want: %v, Got: %v", len(expected), len(owners))
+		}
+		for podName, want := range expected {
+			if got := owners[podName]; got != want {
+				t.Errorf("Invalid owner for %v. Want: %+v, Got: %+v", podName, want, got)
+			}
+		}
+	}
+
+	// ReplicaSets web-5d9f8c7b6, standalone, api-6b7c8d and Job backup-1612345600 are fetched once.
+	if gets != 4 {
+		t.Errorf("Invalid number of get calls. Want: %v, Got: %v", 4, gets)
+	}
+
+	resolver.resolve(context.Background(), pods[3:5])
+	if len(resolver.cache) != 0 {
+		t.Errorf("Cache should be pruned when pods are gone, got: %v", resolver.cache)
+	}
+}
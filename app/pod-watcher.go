@@ -0,0 +1,221 @@
+package app
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"sort"
+	"time"
+)
+
+// minWatchDuration is how long a watch has to stay open to be restarted straight away, watches closed sooner are
+// restarted after a backoff delay.
+const minWatchDuration = time.Second
+
+// errWatchExpired is returned by podWatcher.watch when resource version is too old and pods need to be listed again.
+var errWatchExpired = errors.New("watch expired")
+
+// podWatcher keeps a local copy of pods for a single context/namespace pair. Pods are listed once and then kept up to date
+// by applying watch events, every change is published to updateCh as a full PodListResult for that namespace.
+// Failing namespaces are retried with a growing delay, see backoff.
+type podWatcher struct {
+	context         string
+	namespace       string
+	clientSet       kubernetes.Interface
+	labelSelector   string
+	fieldSelector   string
+	owners          *ownerResolver
+	updateCh        chan<- PodListResult
+	pods            map[string]v1.Pod
+	resourceVersion string
+	reconnecting    bool
+	err             error
+	listDuration    time.Duration
+	backoff         backoff
+	nextRetry       time.Time
+	// sleep waits between retries, it is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) bool
+}
+
+func newPodWatcher(context, namespace string, clientSet kubernetes.Interface, interval time.Duration, updateCh chan<- PodListResult) *podWatcher {
+	return &podWatcher{
+		context:   context,
+		namespace: namespace,
+		clientSet: clientSet,
+		owners:    newOwnerResolver(clientSet, namespace),
+		updateCh:  updateCh,
+		pods:      make(map[string]v1.Pod),
+		backoff:   backoff{interval: interval},
+		sleep:     sleep,
+	}
+}
+
+func (w *podWatcher) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := w.list(ctx); err != nil {
+			w.err = err
+			w.pods = make(map[string]v1.Pod)
+			if !w.retryLater(ctx) {
+				return
+			}
+			continue
+		}
+		w.reconnecting = false
+		w.err = nil
+		w.nextRetry = time.Time{}
+		w.backoff.reset()
+		w.publish(ctx)
+
+		err := w.watchUntilError(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		w.reconnecting = true
+		if err == errWatchExpired {
+			w.publish(ctx)
+			continue
+		}
+		w.err = err
+		if !w.retryLater(ctx) {
+			return
+		}
+	}
+}
+
+// watchUntilError restarts watch from the last known resource version when server closes it, only errors need a new
+// list. Watches closed straight away, e.g. by a proxy, are restarted after a backoff delay, so they do not turn into a
+// tight loop of watch requests. Returns nil when context was cancelled.
+func (w *podWatcher) watchUntilError(ctx context.Context) error {
+	for ctx.Err() == nil {
+		startTime := time.Now()
+		if err := w.watch(ctx); err != nil {
+			return err
+		}
+		if time.Since(startTime) >= minWatchDuration {
+			w.backoff.reset()
+			continue
+		}
+		if !w.sleep(ctx, w.backoff.next()) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// retryLater will publish current state with the next retry time and wait for it, returns false if context was
+// cancelled while waiting.
+func (w *podWatcher) retryLater(ctx context.Context) bool {
+	delay := w.backoff.next()
+	w.nextRetry = time.Now().Add(delay)
+	w.publish(ctx)
+	return w.sleep(ctx, delay)
+}
+
+func (w *podWatcher) list(ctx context.Context) error {
+	startTime := time.Now()
+	podList, err := w.clientSet.CoreV1().Pods(w.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: w.labelSelector,
+		FieldSelector: w.fieldSelector,
+	})
+	w.listDuration = time.Since(startTime)
+	if err != nil {
+		return err
+	}
+
+	w.pods = make(map[string]v1.Pod, len(podList.Items))
+	for _, pod := range podList.Items {
+		w.pods[pod.Name] = pod
+	}
+	w.resourceVersion = podList.ResourceVersion
+	return nil
+}
+
+func (w *podWatcher) watch(ctx context.Context) error {
+	watcher, err := w.clientSet.CoreV1().Pods(w.namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector:       w.labelSelector,
+		FieldSelector:       w.fieldSelector,
+		ResourceVersion:     w.resourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			return errWatchExpired
+		}
+		return err
+	}
+	defer watcher.Stop()
+
+	for event := range watcher.ResultChan() {
+		switch event.Type {
+		case watch.Added, watch.Modified:
+			pod, ok := event.Object.(*v1.Pod)
+			if !ok {
+				continue
+			}
+			w.pods[pod.Name] = *pod
+			w.resourceVersion = pod.ResourceVersion
+		case watch.Deleted:
+			pod, ok := event.Object.(*v1.Pod)
+			if !ok {
+				continue
+			}
+			delete(w.pods, pod.Name)
+			w.resourceVersion = pod.ResourceVersion
+		case watch.Bookmark:
+			if pod, ok := event.Object.(*v1.Pod); ok {
+				w.resourceVersion = pod.ResourceVersion
+			}
+			continue
+		case watch.Error:
+			err := apierrors.FromObject(event.Object)
+			if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+				return errWatchExpired
+			}
+			return err
+		}
+		w.publish(ctx)
+	}
+	return nil
+}
+
+// publish will send a snapshot of current pods, sorted by name the same way List call returns them.
+func (w *podWatcher) publish(ctx context.Context) {
+	items := make([]v1.Pod, 0, len(w.pods))
+	for _, pod := range w.pods {
+		items = append(items, pod)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+
+	result := PodListResult{
+		context:      w.context,
+		namespace:    w.namespace,
+		PodList:      v1.PodList{Items: items},
+		owners:       w.owners.resolve(ctx, items),
+		error:        w.err,
+		reconnecting: w.reconnecting,
+		duration:     w.listDuration,
+		nextRetry:    w.nextRetry,
+	}
+	select {
+	case w.updateCh <- result:
+	case <-ctx.Done():
+	}
+}
+
+// sleep will wait for given duration, returns false if context was cancelled before that.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
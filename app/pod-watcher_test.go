@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWatchClosedStraightAwayIsDelayed(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	watchCount := 0
+	clientSet.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watchCount++
+		// Result channel of a stopped watcher is closed, as if server closed the watch without an error.
+		watcher := watch.NewFake()
+		watcher.Stop()
+		return true, watcher, nil
+	})
+
+	w := newPodWatcher("context", "ns", clientSet, time.Second, make(chan PodListResult, 10))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	delays := make([]time.Duration, 0)
+	w.sleep = func(ctx context.Context, d time.Duration) bool {
+		delays = append(delays, d)
+		if len(delays) == 3 {
+			cancel()
+			return false
+		}
+		return true
+	}
+	if err := w.watchUntilError(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Errorf("Invalid delays before re-watching. Want: %v, Got: %v", expected, delays)
+	}
+	if watchCount != 3 {
+		t.Errorf("Invalid watch count. Want: 3, Got: %v", watchCount)
+	}
+}
+
+func TestWatchEventsDoNotFetchKnownOwners(t *testing.T) {
+	isController := true
+	controlledBy := func(name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: KindReplicaSet, Name: name, Controller: &isController}}
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-5d9f8c7b6-abcde", Namespace: "ns", OwnerReferences: controlledBy("web-5d9f8c7b6")}}
+	clientSet := fake.NewSimpleClientset(
+		pod,
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-5d9f8c7b6", Namespace: "ns", OwnerReferences: []metav1.OwnerReference{
+			{Kind: KindDeployment, Name: "web", Controller: &isController},
+		}}},
+	)
+	var gets int32
+	clientSet.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		atomic.AddInt32(&gets, 1)
+		return false, nil, nil
+	})
+	fakeWatcher := watch.NewFake()
+	clientSet.PrependWatchReactor("pods", k8stesting.DefaultWatchReactor(fakeWatcher, nil))
+
+	updateCh := make(chan PodListResult, 10)
+	w := newPodWatcher("context", "ns", clientSet, time.Second, updateCh)
+	ctx := context.Background()
+	if err := w.list(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	w.publish(ctx)
+	<-updateCh
+
+	done := make(chan error)
+	go func() {
+		done <- w.watch(ctx)
+	}()
+	for i := 0; i < 5; i++ {
+		fakeWatcher.Modify(pod)
+		if owner := (<-updateCh).owners[pod.Name]; owner.Name != "web" {
+			t.Errorf("Invalid owner. Want: web, Got: %+v", owner)
+		}
+	}
+	if count := atomic.LoadInt32(&gets); count != 1 {
+		t.Errorf("Owner should be fetched only once. Want: 1 get call, Got: %v", count)
+	}
+
+	// Pod of a new ReplicaSet is resolved, the ReplicaSet is not found, so Deployment is derived from the name.
+	fakeWatcher.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "web-7f6e5d4c3-fghij",
+		Namespace:       "ns",
+		Labels:          map[string]string{"pod-template-hash": "7f6e5d4c3"},
+		OwnerReferences: controlledBy("web-7f6e5d4c3"),
+	}})
+	<-updateCh
+	fakeWatcher.Stop()
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := atomic.LoadInt32(&gets); count != 2 {
+		t.Errorf("Only the new ReplicaSet should be fetched. Want: 2 get calls, Got: %v", count)
+	}
+}
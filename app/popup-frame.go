@@ -77,7 +77,7 @@ func (pf *PopupFrame) drawItems(s tcell.Screen) {
 	for index, item := range pf.items {
 		style := tcell.StyleDefault
 		if pf.cursorYPos == index {
-			style = tcell.StyleDefault.Reverse(true)
+			style = activeTheme.style(StateSelected)
 		}
 		draw(s, item, pf.x+PopupItemXOffset, pf.y+PopupItemYOffset+index, len(item), style)
 	}
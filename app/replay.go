@@ -0,0 +1,264 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"os"
+	"time"
+)
+
+// maxReplayDelay caps the time between two recorded frames, so replay does not get stuck on long quiet periods.
+const maxReplayDelay = 5 * time.Second
+
+// recordFrame is a single line in the record file, it holds all PodListResults passed to the Gui in one refresh.
+type recordFrame struct {
+	Time    time.Time      `json:"time"`
+	Group   string         `json:"group"`
+	Results []recordResult `json:"results"`
+}
+
+type recordResult struct {
+	Context        string                               `json:"context"`
+	Namespace      string                               `json:"namespace"`
+	PodList        v1.PodList                           `json:"podList"`
+	Error          string                               `json:"error,omitempty"`
+	Reconnecting   bool                                 `json:"reconnecting,omitempty"`
+	Duration       time.Duration                        `json:"duration"`
+	NextRetry      time.Time                            `json:"nextRetry,omitempty"`
+	Owners         map[string]podOwner                  `json:"owners,omitempty"`
+	Events         []v1.Event                           `json:"events,omitempty"`
+	EventsError    string                               `json:"eventsError,omitempty"`
+	Workloads      map[string]workloadStatus            `json:"workloads,omitempty"`
+	WorkloadsError string                               `json:"workloadsError,omitempty"`
+	Metrics        map[string]map[string]containerUsage `json:"metrics,omitempty"`
+	MetricsError   string                               `json:"metricsError,omitempty"`
+}
+
+func toRecordResult(plr *PodListResult) recordResult {
+	rr := recordResult{
+		Context:      plr.context,
+		Namespace:    plr.namespace,
+		PodList:      plr.PodList,
+		Reconnecting: plr.reconnecting,
+		Duration:     plr.duration,
+		NextRetry:    plr.nextRetry,
+		Owners:       plr.owners,
+		Events:       plr.events,
+		Workloads:    plr.workloads,
+		Metrics:      plr.metrics,
+	}
+	if plr.error != nil {
+		rr.Error = plr.Error()
+	}
+	if plr.eventsError != nil {
+		rr.EventsError = plr.eventsError.Error()
+	}
+	if plr.workloadsError != nil {
+		rr.WorkloadsError = plr.workloadsError.Error()
+	}
+	if plr.metricsError != nil {
+		rr.MetricsError = plr.metricsError.Error()
+	}
+	return rr
+}
+
+func (rr *recordResult) toPodListResult() PodListResult {
+	plr := PodListResult{
+		context:      rr.Context,
+		namespace:    rr.Namespace,
+		PodList:      rr.PodList,
+		reconnecting: rr.Reconnecting,
+		duration:     rr.Duration,
+		nextRetry:    rr.NextRetry,
+		owners:       rr.Owners,
+		events:       rr.Events,
+		workloads:    rr.Workloads,
+		metrics:      rr.Metrics,
+	}
+	if rr.Error != "" {
+		plr.error = errors.New(rr.Error)
+	}
+	if rr.EventsError != "" {
+		plr.eventsError = errors.New(rr.EventsError)
+	}
+	if rr.WorkloadsError != "" {
+		plr.workloadsError = errors.New(rr.WorkloadsError)
+	}
+	if rr.MetricsError != "" {
+		plr.metricsError = errors.New(rr.MetricsError)
+	}
+	return plr
+}
+
+// recorder appends every refresh to a file as a json line.
+type recorder struct {
+	file    *os.File
+	encoder *json.Encoder
+	group   string
+}
+
+func newRecorder(path string, group string) (*recorder, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error opening record file %v", path)
+	}
+	return &recorder{file: file, encoder: json.NewEncoder(file), group: group}, nil
+}
+
+func (r *recorder) record(podListResults []PodListResult) error {
+	frame := recordFrame{
+		Time:    time.Now(),
+		Group:   r.group,
+		Results: make([]recordResult, len(podListResults)),
+	}
+	for index := range podListResults {
+		frame.Results[index] = toRecordResult(&podListResults[index])
+	}
+	return r.encoder.Encode(frame)
+}
+
+func (r *recorder) close() error {
+	return r.file.Close()
+}
+
+func readRecordFrames(path string) ([]recordFrame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error opening record file %v", path)
+	}
+	defer file.Close()
+
+	frames := make([]recordFrame, 0)
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	for decoder.More() {
+		var frame recordFrame
+		if err := decoder.Decode(&frame); err != nil {
+			return nil, errors.Wrapf(err, "Error reading frame %v from %v", len(frames)+1, path)
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) == 0 {
+		return nil, errors.New(fmt.Sprintf("no frames found in %v", path))
+	}
+	return frames, nil
+}
+
+type replayControl int
+
+const (
+	replayStepBack replayControl = iota + 1
+	replayStepForward
+	replayTogglePause
+)
+
+// replayer drives the Gui from recorded frames instead of the cluster.
+type replayer struct {
+	frames    []recordFrame
+	position  int
+	paused    bool
+	controlCh chan replayControl
+}
+
+func newReplayer(frames []recordFrame) *replayer {
+	return &replayer{
+		frames:    frames,
+		controlCh: make(chan replayControl),
+	}
+}
+
+// handleKey returns true if key event was a replay control key.
+func (r *replayer) handleKey(ev *tcell.EventKey) bool {
+	switch {
+	case ev.Key() == tcell.KeyCtrlP:
+		r.controlCh <- replayTogglePause
+	case ev.Key() == tcell.KeyRune && ev.Rune() == '[':
+		r.controlCh <- replayStepBack
+	case ev.Key() == tcell.KeyRune && ev.Rune() == ']':
+		r.controlCh <- replayStepForward
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *replayer) run(ctx context.Context, s tcell.Screen, gui *Gui) {
+	r.show(s, gui, 0)
+	for {
+		var next <-chan time.Time
+		if !r.paused && r.position < len(r.frames)-1 {
+			delay := r.frames[r.position+1].Time.Sub(r.frames[r.position].Time)
+			if delay > maxReplayDelay {
+				delay = maxReplayDelay
+			}
+			next = time.After(delay)
+		}
+
+		select {
+		case <-next:
+			r.show(s, gui, r.position+1)
+		case control := <-r.controlCh:
+			switch control {
+			case replayStepBack:
+				r.paused = true
+				r.show(s, gui, r.position-1)
+			case replayStepForward:
+				r.paused = true
+				r.show(s, gui, r.position+1)
+			case replayTogglePause:
+				r.paused = !r.paused
+				r.show(s, gui, r.position)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// show will display the state at given frame, for every namespace the latest result up to that frame is used, so
+// stepping back works the same as stepping forward.
+func (r *replayer) show(s tcell.Screen, gui *Gui, position int) {
+	if position < 0 {
+		position = 0
+	}
+	if position > len(r.frames)-1 {
+		position = len(r.frames) - 1
+	}
+	r.position = position
+
+	latest := make(map[string]PodListResult)
+	order := make([]string, 0)
+	for fIndex := 0; fIndex <= position; fIndex++ {
+		for rIndex := range r.frames[fIndex].Results {
+			plr := r.frames[fIndex].Results[rIndex].toPodListResult()
+			if _, ok := latest[plr.DisplayName()]; !ok {
+				order = append(order, plr.DisplayName())
+			}
+			latest[plr.DisplayName()] = plr
+		}
+	}
+
+	podListResults := make([]PodListResult, 0, len(order))
+	var timeToExec time.Duration
+	for _, name := range order {
+		podListResults = append(podListResults, latest[name])
+		if latest[name].duration > timeToExec {
+			timeToExec = latest[name].duration
+		}
+	}
+
+	frame := r.frames[position]
+	gui.updateNamespaces(s, podListResults, timeToExec)
+
+	state := "playing"
+	if r.paused {
+		state = "paused"
+	} else if position == len(r.frames)-1 {
+		state = "finished"
+	}
+	gui.updateReplayInfo(s, frame.Time, fmt.Sprintf("Group: %v   Replay: %d/%d %v", frame.Group, position+1, len(r.frames), state))
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordAndReadFrames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "record")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "record.jsonl")
+
+	rec, err := newRecorder(path, "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}}
+	frames := [][]PodListResult{
+		{{context: "ctx", namespace: "ns1", PodList: v1.PodList{Items: []v1.Pod{pod}}, duration: time.Second}},
+		{{context: "ctx", namespace: "ns2", error: errors.New("forbidden"), nextRetry: time.Now().Round(0)}},
+	}
+	for _, frame := range frames {
+		if err := rec.record(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := readRecordFrames(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != len(frames) {
+		t.Fatalf("Invalid frame count. Want: %v, Got: %v", len(frames), len(read))
+	}
+	for fIndex := range frames {
+		if read[fIndex].Group != "group" {
+			t.Errorf("Invalid group name. Want: %v, Got: %v", "group", read[fIndex].Group)
+		}
+		for rIndex := range frames[fIndex] {
+			want := frames[fIndex][rIndex]
+			got := read[fIndex].Results[rIndex].toPodListResult()
+			if got.DisplayName() != want.DisplayName() || len(got.Items) != len(want.Items) || got.duration != want.duration ||
+				!got.nextRetry.Equal(want.nextRetry) || (got.error == nil) != (want.error == nil) {
+				t.Errorf("Invalid result in frame %v. Want: %+v, Got: %+v", fIndex, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sortMode defines ordering of pod groups within a namespace and pods within a group.
+type sortMode int
+
+const (
+	SortByName sortMode = iota
+	SortByStatus
+	SortByRestarts
+	SortByAge
+	SortByReady
+)
+
+var sortModeNames = []string{"name", "status", "restarts", "age", "ready"}
+
+func (m sortMode) String() string {
+	return sortModeNames[m]
+}
+
+// next returns the following sort mode, cycling back to SortByName after the last one.
+func (m sortMode) next() sortMode {
+	return (m + 1) % sortMode(len(sortModeNames))
+}
+
+// Status severity levels, higher is worse.
+const (
+	severityHealthy = iota
+	severityProgressing
+	severityFailing
+)
+
+var failingStatusParts = []string{"BackOff", "Err", "Error", "OOMKilled", "Evicted", "Unknown", "ExitCode", "Signal"}
+
+// statusSeverity returns how bad pod status is: healthy, still progressing (e.g. Pending, ContainerCreating, not
+// ready) or failing (e.g. CrashLoopBackOff, ImagePullBackOff, Error).
+func (p *Pod) statusSeverity() int {
+	if p.isHealthy() {
+		return severityHealthy
+	}
+	if p.phase == v1.PodFailed {
+		return severityFailing
+	}
+	for _, part := range failingStatusParts {
+		if strings.Contains(p.status, part) {
+			return severityFailing
+		}
+	}
+	return severityProgressing
+}
+
+// statusSeverity returns the worst severity of group pods, degraded controller is at least progressing.
+func (pg *PodGroup) statusSeverity() int {
+	severity := severityHealthy
+	if pg.workload != nil && pg.workload.isDegraded() {
+		severity = severityProgressing
+	}
+	for pIndex := range pg.pods {
+		if s := pg.pods[pIndex].statusSeverity(); s > severity {
+			severity = s
+		}
+	}
+	return severity
+}
+
+func (pg *PodGroup) countRestarts() (restarts int) {
+	for pIndex := range pg.pods {
+		restarts += pg.pods[pIndex].restarts
+	}
+	return restarts
+}
+
+// newestCreationTime returns creation time of the youngest pod in the group.
+func (pg *PodGroup) newestCreationTime() time.Time {
+	newest := time.Time{}
+	for pIndex := range pg.pods {
+		if pg.pods[pIndex].creationTime.After(newest) {
+			newest = pg.pods[pIndex].creationTime
+		}
+	}
+	return newest
+}
+
+func (p *Pod) readyRatio() float64 {
+	return ratio(p.ready, p.total)
+}
+
+func (pg *PodGroup) readyRatio() float64 {
+	return ratio(pg.countReadyPods(), pg.countActivePods())
+}
+
+func ratio(value, total int) float64 {
+	if total == 0 {
+		return 1
+	}
+	return float64(value) / float64(total)
+}
+
+// sortKey holds values used for comparing pods and pod groups.
+type sortKey struct {
+	name         string
+	severity     int
+	restarts     int
+	creationTime time.Time
+	readyRatio   float64
+}
+
+// less orders worst items first: higher severity, more restarts, younger age and lower ready ratio. Items with equal
+// values are ordered by name.
+func (k sortKey) less(other sortKey, mode sortMode) bool {
+	switch mode {
+	case SortByStatus:
+		if k.severity != other.severity {
+			return k.severity > other.severity
+		}
+	case SortByRestarts:
+		if k.restarts != other.restarts {
+			return k.restarts > other.restarts
+		}
+	case SortByAge:
+		if !k.creationTime.Equal(other.creationTime) {
+			return k.creationTime.After(other.creationTime)
+		}
+	case SortByReady:
+		if k.readyRatio != other.readyRatio {
+			return k.readyRatio < other.readyRatio
+		}
+	}
+	return naturalLess(k.name, other.name)
+}
+
+func (p *Pod) sortKey() sortKey {
+	return sortKey{
+		name:         p.name,
+		severity:     p.statusSeverity(),
+		restarts:     p.restarts,
+		creationTime: p.creationTime,
+		readyRatio:   p.readyRatio(),
+	}
+}
+
+func (pg *PodGroup) sortKey() sortKey {
+	return sortKey{
+		name:         pg.name,
+		severity:     pg.statusSeverity(),
+		restarts:     pg.countRestarts(),
+		creationTime: pg.newestCreationTime(),
+		readyRatio:   pg.readyRatio(),
+	}
+}
+
+// sortNamespace orders pod groups of the namespace and pods within each group.
+func sortNamespace(ns *Namespace, mode sortMode) {
+	for _, pg := range ns.deployments {
+		keys := make(map[string]sortKey, len(pg.pods))
+		for pIndex := range pg.pods {
+			keys[pg.pods[pIndex].name] = pg.pods[pIndex].sortKey()
+		}
+		pods := pg.pods
+		sort.SliceStable(pods, func(i, j int) bool {
+			return keys[pods[i].name].less(keys[pods[j].name], mode)
+		})
+	}
+
+	keys := make(map[*PodGroup]sortKey, len(ns.deployments))
+	for _, pg := range ns.deployments {
+		keys[pg] = pg.sortKey()
+	}
+	groups := ns.deployments
+	sort.SliceStable(groups, func(i, j int) bool {
+		return keys[groups[i]].less(keys[groups[j]], mode)
+	})
+}
+
+// naturalLess compares strings treating digit sequences as numbers, e.g. pod-2 is before pod-10.
+func naturalLess(a, b string) bool {
+	for len(a) > 0 && len(b) > 0 {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			aNum, aRest := splitDigits(a)
+			bNum, bRest := splitDigits(b)
+			aTrimmed := strings.TrimLeft(aNum, "0")
+			bTrimmed := strings.TrimLeft(bNum, "0")
+			if len(aTrimmed) != len(bTrimmed) {
+				return len(aTrimmed) < len(bTrimmed)
+			}
+			if aTrimmed != bTrimmed {
+				return aTrimmed < bTrimmed
+			}
+			if len(aNum) != len(bNum) {
+				return len(aNum) < len(bNum)
+			}
+			a, b = aRest, bRest
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func splitDigits(s string) (digits, rest string) {
+	index := 0
+	for index < len(s) && isDigit(s[index]) {
+		index++
+	}
+	return s[:index], s[index:]
+}
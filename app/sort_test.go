@@ -0,0 +1,83 @@
+package app
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{"pod-10", "pod-2", "pod-1", "pod-02", "pod", "api-3", "pod-1a", "pod-1-b"}
+	sort.Slice(names, func(i, j int) bool {
+		return naturalLess(names[i], names[j])
+	})
+
+	expected := []string{"api-3", "pod", "pod-1", "pod-1-b", "pod-1a", "pod-2", "pod-02", "pod-10"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Invalid order. Want: %v, Got: %v", expected, names)
+	}
+}
+
+func TestSortNamespace(t *testing.T) {
+	now := time.Now()
+	newNamespace := func() *Namespace {
+		ns := &Namespace{name: "ns1", context: "context"}
+		web := &PodGroup{name: "web", namespace: ns}
+		web.pods = []Pod{
+			{name: "web-10", phase: v1.PodRunning, status: "Running", ready: 1, total: 1, restarts: 1, creationTime: now.Add(-time.Hour), podGroup: web},
+			{name: "web-2", phase: v1.PodRunning, status: "CrashLoopBackOff", ready: 0, total: 1, restarts: 7, creationTime: now.Add(-3 * time.Hour), podGroup: web},
+			{name: "web-1", phase: v1.PodPending, status: "ContainerCreating", ready: 0, total: 2, creationTime: now, podGroup: web},
+		}
+		api := &PodGroup{name: "api", namespace: ns}
+		api.pods = []Pod{
+			{name: "api-1", phase: v1.PodRunning, status: "Running", ready: 1, total: 2, restarts: 2, creationTime: now.Add(-2 * time.Hour), podGroup: api},
+		}
+		db := &PodGroup{name: "db", namespace: ns}
+		db.pods = []Pod{
+			{name: "db-0", phase: v1.PodRunning, status: "Running", ready: 1, total: 1, creationTime: now.Add(-5 * time.Hour), podGroup: db},
+		}
+		ns.deployments = []*PodGroup{web, db, api}
+		return ns
+	}
+
+	testTable := []struct {
+		mode           sortMode
+		expectedGroups []string
+		expectedPods   []string
+	}{
+		{mode: SortByName, expectedGroups: []string{"api", "db", "web"}, expectedPods: []string{"web-1", "web-2", "web-10"}},
+		{mode: SortByStatus, expectedGroups: []string{"web", "api", "db"}, expectedPods: []string{"web-2", "web-1", "web-10"}},
+		{mode: SortByRestarts, expectedGroups: []string{"web", "api", "db"}, expectedPods: []string{"web-2", "web-10", "web-1"}},
+		{mode: SortByAge, expectedGroups: []string{"web", "api", "db"}, expectedPods: []string{"web-1", "web-10", "web-2"}},
+		{mode: SortByReady, expectedGroups: []string{"api", "web", "db"}, expectedPods: []string{"web-1", "web-2", "web-10"}},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.mode.String(), func(t *testing.T) {
+			ns := newNamespace()
+			sortNamespace(ns, tc.mode)
+
+			groups := make([]string, 0)
+			var web *PodGroup
+			for _, pg := range ns.deployments {
+				groups = append(groups, pg.name)
+				if pg.name == "web" {
+					web = pg
+				}
+			}
+			pods := web.podNames()
+			if !reflect.DeepEqual(groups, tc.expectedGroups) {
+				t.Errorf("Invalid group order. Want: %v, Got: %v", tc.expectedGroups, groups)
+			}
+			if !reflect.DeepEqual(pods, tc.expectedPods) {
+				t.Errorf("Invalid pod order. Want: %v, Got: %v", tc.expectedPods, pods)
+			}
+		})
+	}
+
+	if SortByReady.next() != SortByName {
+		t.Errorf("Sort mode should cycle back to name, got: %v", SortByReady.next())
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"io"
+	"sync"
+)
+
+// syncScreen serializes screen updates with raw writes to the terminal, so escape sequences tcell does not provide,
+// e.g. OSC 52 clipboard, are not written in the middle of a screen update.
+type syncScreen struct {
+	tcell.Screen
+	sync.Mutex
+	out io.Writer
+}
+
+func (s *syncScreen) Show() {
+	s.Lock()
+	s.Screen.Show()
+	s.Unlock()
+}
+
+func (s *syncScreen) Sync() {
+	s.Lock()
+	s.Screen.Sync()
+	s.Unlock()
+}
+
+// writeRaw writes sequence to the terminal between screen updates.
+func (s *syncScreen) writeRaw(sequence string) error {
+	s.Lock()
+	defer s.Unlock()
+	_, err := io.WriteString(s.out, sequence)
+	return err
+}
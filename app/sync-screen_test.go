@@ -0,0 +1,21 @@
+package app
+
+import (
+	"bytes"
+	"github.com/gdamore/tcell/v2"
+	"testing"
+)
+
+func TestSyncScreenWriteRaw(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := &syncScreen{Screen: tcell.NewSimulationScreen(""), out: out}
+	_ = s.Init()
+
+	s.Show()
+	if err := s.writeRaw("\x1b]52;c;aGVsbG8=\x07"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.String() != "\x1b]52;c;aGVsbG8=\x07" {
+		t.Errorf("Sequence should be written to terminal, got: %q", out.String())
+	}
+}
@@ -0,0 +1,320 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"unicode/utf8"
+)
+
+const (
+	// TextViewHScrollStep is number of columns text is shifted by left/right keys when wrapping is off.
+	TextViewHScrollStep = 8
+)
+
+// textLine is a single line of TextView, prefix is drawn before the text with its own style.
+type textLine struct {
+	prefix      string
+	prefixStyle tcell.Style
+	text        string
+	style       tcell.Style
+}
+
+func (l *textLine) runes() []rune {
+	return []rune(l.prefix + l.text)
+}
+
+// textRow is a part of the line displayed on a single screen row, start and end are rune positions in the line.
+type textRow struct {
+	line       int
+	start, end int
+}
+
+// TextView is a scrollable list of text lines drawn in a full screen frame with a title line on the top and a help
+// (or search input) line on the bottom. Long lines are either wrapped or cut, in which case they can be scrolled
+// horizontally.
+type TextView struct {
+	x, y          int
+	width, height int
+	lines         []textLine
+	// topRow is the first displayed row, rows depend on width when wrapping is on.
+	topRow  int
+	xOffset int
+	wrap    bool
+	// search highlights matches and is used by n/N to jump between matching lines.
+	search      *filter
+	searchInput bool
+	searchText  string
+}
+
+// resize will make view to take the whole screen excluding the status bar line.
+func (v *TextView) resize(winWidth, winHeight int) {
+	v.x = 0
+	v.y = 0
+	v.width = winWidth
+	v.height = winHeight - 1
+}
+
+// contentHeight is number of rows available for text lines.
+func (v *TextView) contentHeight() int {
+	if v.height < 2 {
+		return 0
+	}
+	return v.height - 2
+}
+
+// lineRows splits line into rows, there is always at least one row even for empty lines.
+func (v *TextView) lineRows(index int) []textRow {
+	length := utf8.RuneCountInString(v.lines[index].prefix) + utf8.RuneCountInString(v.lines[index].text)
+	if !v.wrap || v.width <= 0 || length <= v.width {
+		return []textRow{{line: index, start: 0, end: length}}
+	}
+	rows := make([]textRow, 0, length/v.width+1)
+	for start := 0; start < length; start += v.width {
+		end := start + v.width
+		if end > length {
+			end = length
+		}
+		rows = append(rows, textRow{line: index, start: start, end: end})
+	}
+	return rows
+}
+
+func (v *TextView) rows() []textRow {
+	rows := make([]textRow, 0, len(v.lines))
+	for index := range v.lines {
+		rows = append(rows, v.lineRows(index)...)
+	}
+	return rows
+}
+
+func (v *TextView) maxTopRow() int {
+	maxTop := len(v.rows()) - v.contentHeight()
+	if maxTop < 0 {
+		return 0
+	}
+	return maxTop
+}
+
+// atEnd returns true when the last line is displayed.
+func (v *TextView) atEnd() bool {
+	return v.topRow >= v.maxTopRow()
+}
+
+func (v *TextView) scroll(n int) {
+	v.topRow += n
+	if maxTop := v.maxTopRow(); v.topRow > maxTop {
+		v.topRow = maxTop
+	}
+	if v.topRow < 0 {
+		v.topRow = 0
+	}
+}
+
+func (v *TextView) scrollToEnd() {
+	v.topRow = v.maxTopRow()
+}
+
+func (v *TextView) scrollHorizontally(n int) {
+	if v.wrap {
+		return
+	}
+	v.xOffset += n
+	if v.xOffset < 0 {
+		v.xOffset = 0
+	}
+}
+
+func (v *TextView) toggleWrap() {
+	// Keep the first displayed line on the top after rows are recalculated.
+	line := v.topLine()
+	v.wrap = !v.wrap
+	v.xOffset = 0
+	v.scrollToLine(line)
+}
+
+// topLine returns index of the line displayed on the top.
+func (v *TextView) topLine() int {
+	rows := v.rows()
+	if v.topRow < len(rows) {
+		return rows[v.topRow].line
+	}
+	return 0
+}
+
+func (v *TextView) scrollToLine(line int) {
+	for index, row := range v.rows() {
+		if row.line == line {
+			v.topRow = index
+			break
+		}
+	}
+	v.scroll(0)
+}
+
+// dropLines removes first n lines keeping the displayed text in place.
+func (v *TextView) dropLines(n int) {
+	if n <= 0 {
+		return
+	}
+	if n > len(v.lines) {
+		n = len(v.lines)
+	}
+	droppedRows := 0
+	for index := 0; index < n; index++ {
+		droppedRows += len(v.lineRows(index))
+	}
+	v.lines = v.lines[n:]
+	v.topRow -= droppedRows
+	if v.topRow < 0 {
+		v.topRow = 0
+	}
+}
+
+// nextMatch moves view to the next (or previous when forward is false) line matching search, search wraps around.
+func (v *TextView) nextMatch(forward bool) {
+	if v.search == nil || len(v.lines) == 0 {
+		return
+	}
+	current := v.topLine()
+	for step := 1; step <= len(v.lines); step++ {
+		index := current + step
+		if !forward {
+			index = current - step
+		}
+		index = (index%len(v.lines) + len(v.lines)) % len(v.lines)
+		if v.search.matches(v.lines[index].prefix + v.lines[index].text) {
+			v.scrollToLine(index)
+			return
+		}
+	}
+}
+
+func (v *TextView) startSearch() {
+	v.searchInput = true
+	v.searchText = ""
+	v.search = nil
+}
+
+// handleSearchKey edits search text, view jumps to the first match after the top line on every change.
+func (v *TextView) handleSearchKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		v.searchInput = false
+		return
+	case tcell.KeyEscape:
+		v.searchInput = false
+		v.searchText = ""
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(v.searchText) > 0 {
+			v.searchText = v.searchText[:len(v.searchText)-1]
+		}
+	case tcell.KeyRune:
+		v.searchText += string(ev.Rune())
+	default:
+		return
+	}
+	v.search = newFilter(v.searchText)
+	if len(v.lines) == 0 {
+		return
+	}
+	if top := &v.lines[v.topLine()]; !v.search.matches(top.prefix + top.text) {
+		v.nextMatch(true)
+	}
+}
+
+// handleKey handles scrolling, wrapping and search keys, returns false if key was not used.
+func (v *TextView) handleKey(ev *tcell.EventKey) bool {
+	if v.searchInput {
+		v.handleSearchKey(ev)
+		return true
+	}
+	switch ev.Key() {
+	case tcell.KeyDown:
+		v.scroll(1)
+	case tcell.KeyUp:
+		v.scroll(-1)
+	case tcell.KeyPgDn:
+		v.scroll(v.contentHeight())
+	case tcell.KeyPgUp:
+		v.scroll(-v.contentHeight())
+	case tcell.KeyHome:
+		v.topRow = 0
+	case tcell.KeyEnd:
+		v.scrollToEnd()
+	case tcell.KeyLeft:
+		v.scrollHorizontally(-TextViewHScrollStep)
+	case tcell.KeyRight:
+		v.scrollHorizontally(TextViewHScrollStep)
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'w':
+			v.toggleWrap()
+		case '/':
+			v.startSearch()
+		case 'n', 'N':
+			v.nextMatch(ev.Rune() == 'n')
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// draw renders title, visible rows and help line, search input replaces help while it is typed.
+func (v *TextView) draw(s tcell.Screen, title, help string) {
+	drawS(s, title, v.x, v.y, v.width, activeTheme.style(StateTitle))
+
+	rows := v.rows()
+	for y := 0; y < v.contentHeight(); y++ {
+		rowIndex := v.topRow + y
+		if rowIndex >= len(rows) {
+			drawS(s, "", v.x, v.y+1+y, v.width, tcell.StyleDefault)
+			continue
+		}
+		v.drawRow(s, rows[rowIndex], v.y+1+y)
+	}
+
+	if v.searchInput {
+		help = "/" + v.searchText
+	} else if v.search != nil {
+		help = "Search: " + v.searchText + "  (n/N next/previous match)  " + help
+	}
+	if v.height > 1 {
+		drawS(s, help, v.x, v.y+v.height-1, v.width, activeTheme.style(StateFooter))
+	}
+}
+
+func (v *TextView) drawRow(s tcell.Screen, row textRow, y int) {
+	line := &v.lines[row.line]
+	runes := line.runes()
+	prefixLength := utf8.RuneCountInString(line.prefix)
+
+	matchStart, matchEnd := -1, -1
+	if loc := v.search.find(string(runes)); loc != nil {
+		full := string(runes)
+		matchStart = utf8.RuneCountInString(full[:loc[0]])
+		matchEnd = utf8.RuneCountInString(full[:loc[1]])
+	}
+
+	start := row.start
+	if !v.wrap {
+		start += v.xOffset
+	}
+	for x := 0; x < v.width; x++ {
+		pos := start + x
+		r := ' '
+		style := tcell.StyleDefault
+		if pos < row.end {
+			r = runes[pos]
+			style = line.style
+			if pos < prefixLength {
+				style = line.prefixStyle
+			}
+			if pos >= matchStart && pos < matchEnd {
+				style = style.Reverse(true)
+			}
+		}
+		s.SetContent(v.x+x, y, r, nil, style)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestTextView(texts ...string) *TextView {
+	v := &TextView{}
+	v.resize(10, 5)
+	for _, text := range texts {
+		v.lines = append(v.lines, textLine{text: text})
+	}
+	return v
+}
+
+func TestTextViewRows(t *testing.T) {
+	v := newTestTextView("short", "exactly 10", "this line is wrapped", "")
+
+	expected := []textRow{{0, 0, 5}, {1, 0, 10}, {2, 0, 20}, {3, 0, 0}}
+	if rows := v.rows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Invalid rows. Want: %v, Got: %v", expected, rows)
+	}
+
+	v.toggleWrap()
+	expected = []textRow{{0, 0, 5}, {1, 0, 10}, {2, 0, 10}, {2, 10, 20}, {3, 0, 0}}
+	if rows := v.rows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Invalid wrapped rows. Want: %v, Got: %v", expected, rows)
+	}
+}
+
+func TestTextViewScroll(t *testing.T) {
+	v := newTestTextView("1", "2", "3", "4", "5", "6")
+
+	v.scroll(10)
+	if v.topRow != 4 || !v.atEnd() {
+		t.Errorf("Scroll should stop at the end. Want: %v, Got: %v", 4, v.topRow)
+	}
+
+	v.dropLines(2)
+	if v.topRow != 2 || v.lines[v.topLine()].text != "5" {
+		t.Errorf("Dropping lines should keep the view in place. Got top row: %v", v.topRow)
+	}
+
+	v.scroll(-10)
+	if v.topRow != 0 {
+		t.Errorf("Scroll should stop at the top. Want: %v, Got: %v", 0, v.topRow)
+	}
+}
+
+func TestTextViewNextMatch(t *testing.T) {
+	v := newTestTextView("info a", "error b", "info c", "error d", "info e", "info f", "info g")
+	v.search = newFilter("error")
+
+	testTable := []struct {
+		forward  bool
+		expected int
+	}{
+		{forward: true, expected: 1},
+		{forward: true, expected: 3},
+		{forward: true, expected: 1},
+		{forward: false, expected: 3},
+	}
+
+	for _, tc := range testTable {
+		v.nextMatch(tc.forward)
+		if line := v.topLine(); line != tc.expected {
+			t.Errorf("Invalid match line. Want: %v, Got: %v", tc.expected, line)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"os"
+	"strings"
+)
+
+const (
+	ThemeDefault    = "default"
+	ThemeLight      = "light"
+	ThemeColorBlind = "colorblind"
+
+	StateHealthy  themeState = "healthy"
+	StateDegraded themeState = "degraded"
+	StateFailed   themeState = "failed"
+	StateWarning  themeState = "warning"
+	StateError    themeState = "error"
+	StateSelected themeState = "selected"
+	StateHeader   themeState = "header"
+	StateFooter   themeState = "footer"
+	StateTitle    themeState = "title"
+)
+
+// themeState is a semantic state of displayed value, mapped to a style by Theme.
+type themeState string
+
+var themeStates = []themeState{StateHealthy, StateDegraded, StateFailed, StateWarning, StateError, StateSelected, StateHeader, StateFooter, StateTitle}
+
+// stateSymbols are shown after item names when colours are not used, they have to be single byte characters.
+var stateSymbols = map[themeState]string{
+	StateHealthy:  "+",
+	StateDegraded: "~",
+	StateFailed:   "!",
+}
+
+var themeAttributes = map[string]tcell.AttrMask{
+	"bold":      tcell.AttrBold,
+	"dim":       tcell.AttrDim,
+	"underline": tcell.AttrUnderline,
+	"reverse":   tcell.AttrReverse,
+	"blink":     tcell.AttrBlink,
+}
+
+// activeTheme is used for drawing by all frames, it is set when the app starts.
+var activeTheme = defaultTheme()
+
+type Theme struct {
+	styles map[themeState]tcell.Style
+	// symbols adds a state symbol after item names, so state can be told without colours.
+	symbols bool
+	// noColour disables colours which are not mapped to a state, e.g. log prefixes.
+	noColour bool
+}
+
+func defaultTheme() *Theme {
+	return &Theme{styles: map[themeState]tcell.Style{
+		StateHealthy:  tcell.StyleDefault.Foreground(tcell.ColorGreen),
+		StateDegraded: tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		StateFailed:   tcell.StyleDefault.Foreground(tcell.ColorRed),
+		StateWarning:  tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		StateError:    tcell.StyleDefault.Foreground(tcell.ColorRed),
+		StateSelected: tcell.StyleDefault.Reverse(true),
+		StateHeader:   tcell.StyleDefault,
+		StateFooter:   tcell.StyleDefault,
+		StateTitle:    tcell.StyleDefault.Reverse(true),
+	}}
+}
+
+// lightTheme uses darker colours readable on light terminal backgrounds.
+func lightTheme() *Theme {
+	return &Theme{styles: map[themeState]tcell.Style{
+		StateHealthy:  tcell.StyleDefault.Foreground(tcell.ColorDarkGreen),
+		StateDegraded: tcell.StyleDefault.Foreground(tcell.ColorDarkOrange),
+		StateFailed:   tcell.StyleDefault.Foreground(tcell.ColorDarkRed),
+		StateWarning:  tcell.StyleDefault.Foreground(tcell.ColorDarkGoldenrod),
+		StateError:    tcell.StyleDefault.Foreground(tcell.ColorDarkRed),
+		StateSelected: tcell.StyleDefault.Reverse(true),
+		StateHeader:   tcell.StyleDefault.Bold(true),
+		StateFooter:   tcell.StyleDefault.Foreground(tcell.ColorGray),
+		StateTitle:    tcell.StyleDefault.Reverse(true),
+	}}
+}
+
+// colorBlindTheme uses Okabe-Ito palette, which avoids red/green pairs, and shows state symbols as well.
+func colorBlindTheme() *Theme {
+	return &Theme{styles: map[themeState]tcell.Style{
+		StateHealthy:  tcell.StyleDefault.Foreground(tcell.NewHexColor(0x56B4E9)),
+		StateDegraded: tcell.StyleDefault.Foreground(tcell.NewHexColor(0xE69F00)),
+		StateFailed:   tcell.StyleDefault.Foreground(tcell.NewHexColor(0xD55E00)).Bold(true),
+		StateWarning:  tcell.StyleDefault.Foreground(tcell.NewHexColor(0xF0E442)),
+		StateError:    tcell.StyleDefault.Foreground(tcell.NewHexColor(0xD55E00)).Bold(true),
+		StateSelected: tcell.StyleDefault.Reverse(true),
+		StateHeader:   tcell.StyleDefault,
+		StateFooter:   tcell.StyleDefault,
+		StateTitle:    tcell.StyleDefault.Reverse(true),
+	}, symbols: true}
+}
+
+// noColorTheme is used when NO_COLOR is set, states are shown with symbols and attributes only.
+func noColorTheme() *Theme {
+	return &Theme{styles: map[themeState]tcell.Style{
+		StateHealthy:  tcell.StyleDefault,
+		StateDegraded: tcell.StyleDefault,
+		StateFailed:   tcell.StyleDefault.Bold(true),
+		StateWarning:  tcell.StyleDefault,
+		StateError:    tcell.StyleDefault.Bold(true),
+		StateSelected: tcell.StyleDefault.Reverse(true),
+		StateHeader:   tcell.StyleDefault,
+		StateFooter:   tcell.StyleDefault,
+		StateTitle:    tcell.StyleDefault.Reverse(true),
+	}, symbols: true, noColour: true}
+}
+
+func (t *Theme) style(state themeState) tcell.Style {
+	return t.styles[state]
+}
+
+// colour returns style with given foreground colour, colours are ignored when theme has no colours.
+func (t *Theme) colour(colour tcell.Color) tcell.Style {
+	if t.noColour {
+		return tcell.StyleDefault
+	}
+	return tcell.StyleDefault.Foreground(colour)
+}
+
+// symbol returns state symbol prefixed with a space when symbols are enabled, empty string otherwise.
+func (t *Theme) symbol(state themeState) string {
+	symbol, ok := stateSymbols[state]
+	if !t.symbols || !ok {
+		return ""
+	}
+	return " " + symbol
+}
+
+// symbolWidth is width added to item names by symbol.
+func (t *Theme) symbolWidth() int {
+	if !t.symbols {
+		return 0
+	}
+	return 2
+}
+
+// noColor follows https://no-color.org, colours are not used when NO_COLOR is set to any non empty value.
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// getTheme reads 'theme' setting. 'preset' selects one of the built in themes, which can be adjusted per state with
+// 'fg', 'bg' and 'attrs'. NO_COLOR takes precedence over the setting.
+func getTheme(settings map[string]interface{}) (*Theme, error) {
+	if noColor() {
+		return noColorTheme(), nil
+	}
+	value, ok := settings["theme"]
+	if !ok {
+		return defaultTheme(), nil
+	}
+	m, ok := toStringMap(value)
+	if !ok {
+		return nil, errors.New("theme should be a map of preset and states")
+	}
+
+	preset, _ := m["preset"].(string)
+	var theme *Theme
+	switch preset {
+	case "", ThemeDefault:
+		theme = defaultTheme()
+	case ThemeLight:
+		theme = lightTheme()
+	case ThemeColorBlind:
+		theme = colorBlindTheme()
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid theme preset '%v', should be one of %v, %v or %v", preset, ThemeDefault, ThemeLight, ThemeColorBlind))
+	}
+	if symbols, ok := m["symbols"].(bool); ok {
+		theme.symbols = symbols
+	}
+
+	for _, state := range themeStates {
+		value, ok := m[string(state)]
+		if !ok {
+			continue
+		}
+		style, err := parseThemeStyle(theme.styles[state], value)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid theme '%v': %v", state, err))
+		}
+		theme.styles[state] = style
+	}
+	return theme, nil
+}
+
+// parseThemeStyle applies 'fg', 'bg' and 'attrs' on base style. Colours are names or #rrggbb values understood by
+// tcell, 'default' is terminal default colour. Attributes replace attributes of base style.
+func parseThemeStyle(base tcell.Style, value interface{}) (tcell.Style, error) {
+	m, ok := toStringMap(value)
+	if !ok {
+		return base, errors.New("should be a map with fg, bg and attrs")
+	}
+
+	style := base
+	if fg, ok := m["fg"].(string); ok {
+		colour, err := parseThemeColour(fg)
+		if err != nil {
+			return base, err
+		}
+		style = style.Foreground(colour)
+	}
+	if bg, ok := m["bg"].(string); ok {
+		colour, err := parseThemeColour(bg)
+		if err != nil {
+			return base, err
+		}
+		style = style.Background(colour)
+	}
+	if value, ok := m["attrs"]; ok {
+		attrs, ok := value.([]interface{})
+		if !ok {
+			return base, errors.New("attrs should be a list")
+		}
+		var mask tcell.AttrMask
+		for _, attr := range attrs {
+			name, _ := attr.(string)
+			a, ok := themeAttributes[strings.ToLower(name)]
+			if !ok {
+				return base, errors.New(fmt.Sprintf("unknown attribute '%v'", attr))
+			}
+			mask |= a
+		}
+		style = style.Attributes(mask)
+	}
+	return style, nil
+}
+
+func parseThemeColour(name string) (tcell.Color, error) {
+	if strings.ToLower(name) == "default" {
+		return tcell.ColorDefault, nil
+	}
+	colour := tcell.GetColor(strings.ToLower(name))
+	if colour == tcell.ColorDefault {
+		return colour, errors.New(fmt.Sprintf("unknown colour '%v'", name))
+	}
+	return colour, nil
+}
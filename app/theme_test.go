@@ -0,0 +1,143 @@
+package app
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"os"
+	"testing"
+)
+
+func TestGetTheme(t *testing.T) {
+	noColorValue, noColorSet := os.LookupEnv("NO_COLOR")
+	_ = os.Unsetenv("NO_COLOR")
+	defer func() {
+		if noColorSet {
+			_ = os.Setenv("NO_COLOR", noColorValue)
+		}
+	}()
+
+	testTable := []struct {
+		name            string
+		settings        map[string]interface{}
+		state           themeState
+		expected        tcell.Style
+		expectedSymbols bool
+		expectErr       bool
+	}{
+		{
+			name:     "default_when_not_set",
+			settings: map[string]interface{}{},
+			state:    StateFailed,
+			expected: tcell.StyleDefault.Foreground(tcell.ColorRed),
+		},
+		{
+			name:     "light_preset",
+			settings: map[string]interface{}{"theme": map[string]interface{}{"preset": "light"}},
+			state:    StateHealthy,
+			expected: tcell.StyleDefault.Foreground(tcell.ColorDarkGreen),
+		},
+		{
+			name:            "colorblind_preset_has_symbols",
+			settings:        map[string]interface{}{"theme": map[string]interface{}{"preset": "colorblind"}},
+			state:           StateDegraded,
+			expected:        tcell.StyleDefault.Foreground(tcell.NewHexColor(0xE69F00)),
+			expectedSymbols: true,
+		},
+		{
+			name: "state_override",
+			settings: map[string]interface{}{"theme": map[string]interface{}{
+				"healthy": map[interface{}]interface{}{"fg": "#00ff00", "bg": "default", "attrs": []interface{}{"bold", "Underline"}},
+			}},
+			state:    StateHealthy,
+			expected: tcell.StyleDefault.Foreground(tcell.NewHexColor(0x00FF00)).Background(tcell.ColorDefault).Bold(true).Underline(true),
+		},
+		{
+			name: "override_keeps_preset_colour",
+			settings: map[string]interface{}{"theme": map[string]interface{}{
+				"preset":  "light",
+				"warning": map[string]interface{}{"bg": "white"},
+			}},
+			state:    StateWarning,
+			expected: tcell.StyleDefault.Foreground(tcell.ColorDarkGoldenrod).Background(tcell.ColorWhite),
+		},
+		{
+			name:     "title_override",
+			settings: map[string]interface{}{"theme": map[string]interface{}{"title": map[string]interface{}{"fg": "blue"}}},
+			state:    StateTitle,
+			expected: tcell.StyleDefault.Reverse(true).Foreground(tcell.ColorBlue),
+		},
+		{
+			name:            "symbols_switch",
+			settings:        map[string]interface{}{"theme": map[string]interface{}{"symbols": true}},
+			state:           StateError,
+			expected:        tcell.StyleDefault.Foreground(tcell.ColorRed),
+			expectedSymbols: true,
+		},
+		{
+			name:      "invalid_preset",
+			settings:  map[string]interface{}{"theme": map[string]interface{}{"preset": "dark"}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid_colour",
+			settings:  map[string]interface{}{"theme": map[string]interface{}{"failed": map[string]interface{}{"fg": "reddish"}}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid_attribute",
+			settings:  map[string]interface{}{"theme": map[string]interface{}{"failed": map[string]interface{}{"attrs": []interface{}{"shiny"}}}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testTable {
+		t.Run(tc.name, func(t *testing.T) {
+			theme, err := getTheme(tc.settings)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("Expected error, got theme %v", theme)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := theme.style(tc.state); got != tc.expected {
+				t.Errorf("Invalid style. Want: %v, Got: %v", tc.expected, got)
+			}
+			if theme.symbols != tc.expectedSymbols {
+				t.Errorf("Invalid symbols. Want: %v, Got: %v", tc.expectedSymbols, theme.symbols)
+			}
+		})
+	}
+}
+
+func TestGetThemeNoColor(t *testing.T) {
+	noColorValue, noColorSet := os.LookupEnv("NO_COLOR")
+	_ = os.Setenv("NO_COLOR", "1")
+	defer func() {
+		if noColorSet {
+			_ = os.Setenv("NO_COLOR", noColorValue)
+		} else {
+			_ = os.Unsetenv("NO_COLOR")
+		}
+	}()
+
+	theme, err := getTheme(map[string]interface{}{"theme": map[string]interface{}{"preset": "light"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, state := range themeStates {
+		if fg, bg, _ := theme.style(state).Decompose(); fg != tcell.ColorDefault || bg != tcell.ColorDefault {
+			t.Errorf("State %v should not have colours, got fg %v, bg %v", state, fg, bg)
+		}
+	}
+	if got := theme.colour(tcell.ColorGreen); got != tcell.StyleDefault {
+		t.Errorf("Colour should be ignored, got %v", got)
+	}
+	if got := theme.symbol(StateFailed); got != " !" {
+		t.Errorf("Invalid failed symbol. Want: ' !', Got: '%v'", got)
+	}
+	if got := theme.symbol(StateWarning); got != "" {
+		t.Errorf("Warning should not have a symbol, got '%v'", got)
+	}
+}
@@ -20,6 +20,9 @@ const (
 	TypeContainer
 	TypeNamespaceError
 	TypeNamespaceMessage
+	TypeEvents
+	TypeEvent
+	TypeContainerDetail
 )
 
 func (t Type) String() string {
@@ -30,7 +33,10 @@ func (t Type) String() string {
 		"Pod",
 		"Container",
 		"NamespaceError",
-		"NamespaceMessage"}[t]
+		"NamespaceMessage",
+		"Events",
+		"Event",
+		"ContainerDetail"}[t]
 }
 
 func toType(s string) (Type, error) {
@@ -54,23 +60,36 @@ func toType(s string) (Type, error) {
 
 type Item interface {
 	Type() Type
+	// Level is a depth of the item in the tree, namespaces are at level 0.
+	Level() int
 	Expanded(b bool)
 	IsExpanded() bool
 }
 
 type Namespace struct {
-	name        string
-	context     string
-	deployments []*PodGroup
-	nsError     NamespaceError
-	nsMessage   NamespaceMessage
-	isExpanded  bool
+	name         string
+	context      string
+	deployments  []*PodGroup
+	nsError      NamespaceError
+	nsMessage    NamespaceMessage
+	events       Events
+	isExpanded   bool
+	reconnecting bool
+	nextRetry    time.Time
+	// metricsUnavailable is set when metrics were requested, but could not be fetched for this namespace.
+	metricsUnavailable bool
+	// workloadsError is set when controllers could not be listed, their status is not shown on pod groups then.
+	workloadsError error
 }
 
 func (n *Namespace) Type() Type {
 	return TypeNamespace
 }
 
+func (n *Namespace) Level() int {
+	return 0
+}
+
 func (n *Namespace) Expanded(b bool) {
 	n.isExpanded = b
 }
@@ -79,13 +98,34 @@ func (n *Namespace) IsExpanded() bool {
 	return n.isExpanded
 }
 
+// isHealthy returns true when pods were listed without error and all pod groups are healthy.
+func (n *Namespace) isHealthy() bool {
+	if n.nsError.error != nil {
+		return false
+	}
+	for dIndex := range n.deployments {
+		if !n.deployments[dIndex].isHealthy() {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *Namespace) DisplayName() string {
-	return fmt.Sprintf("%v / %v", n.name, n.context)
+	return nsDisplayName(n.name, n.context)
+}
+
+func nsDisplayName(namespace, context string) string {
+	return fmt.Sprintf("%v / %v", namespace, context)
 }
 
 type PodGroup struct {
-	name       string
+	name string
+	// ownerKind is the kind of top level controller of the first pod in the group, empty when pods have no controller.
+	ownerKind  string
 	pods       []Pod
+	workload   *workloadStatus
+	events     []Event
 	isExpanded bool
 	namespace  *Namespace
 }
@@ -94,6 +134,10 @@ func (pg *PodGroup) Type() Type {
 	return TypePodGroup
 }
 
+func (pg *PodGroup) Level() int {
+	return 1
+}
+
 func (pg *PodGroup) Expanded(b bool) {
 	pg.isExpanded = b
 }
@@ -102,10 +146,14 @@ func (pg *PodGroup) IsExpanded() bool {
 	return pg.isExpanded
 }
 
+// countReadyPods returns number of ready pods, completed pods are not counted, see countActivePods.
 func (pg *PodGroup) countReadyPods() (ready int) {
 	ready = 0
 
 	for pIndex := range pg.pods {
+		if pg.pods[pIndex].isCompleted() {
+			continue
+		}
 		if pg.pods[pIndex].ready == pg.pods[pIndex].total {
 			ready++
 		}
@@ -113,6 +161,29 @@ func (pg *PodGroup) countReadyPods() (ready int) {
 	return ready
 }
 
+// isHealthy returns true when all pods are healthy and owning controller is not degraded.
+func (pg *PodGroup) isHealthy() bool {
+	if pg.workload != nil && pg.workload.isDegraded() {
+		return false
+	}
+	for pIndex := range pg.pods {
+		if !pg.pods[pIndex].isHealthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// countActivePods returns number of pods excluding completed ones, e.g. finished Job pods.
+func (pg *PodGroup) countActivePods() (active int) {
+	for pIndex := range pg.pods {
+		if !pg.pods[pIndex].isCompleted() {
+			active++
+		}
+	}
+	return active
+}
+
 func (pg *PodGroup) podNames() []string {
 	names := make([]string, 0)
 	for index := range pg.pods {
@@ -122,22 +193,33 @@ func (pg *PodGroup) podNames() []string {
 }
 
 type Pod struct {
-	name         string
-	ready        int
-	total        int
-	status       string
-	restarts     int
-	age          string
-	creationTime time.Time
-	containers   []Container
-	isExpanded   bool
-	podGroup     *PodGroup
+	name          string
+	controllerKey string
+	owner         podOwner
+	phase         v1.PodPhase
+	ready         int
+	total         int
+	status        string
+	restarts      int
+	age           string
+	creationTime  time.Time
+	// lastRestart is finish time of the most recent terminated container run which was restarted.
+	lastRestart time.Time
+	containers  []Container
+	details     podDetails
+	events      []Event
+	isExpanded  bool
+	podGroup    *PodGroup
 }
 
 func (p *Pod) Type() Type {
 	return TypePod
 }
 
+func (p *Pod) Level() int {
+	return 2
+}
+
 func (p *Pod) Expanded(b bool) {
 	p.isExpanded = b
 }
@@ -146,6 +228,16 @@ func (p *Pod) IsExpanded() bool {
 	return p.isExpanded
 }
 
+// isCompleted returns true for pods which finished successfully, e.g. Job pods.
+func (p *Pod) isCompleted() bool {
+	return p.phase == v1.PodSucceeded
+}
+
+// isHealthy returns true for running pods with all containers ready and for completed pods.
+func (p *Pod) isHealthy() bool {
+	return (p.status == "Running" && p.ready >= p.total) || p.isCompleted()
+}
+
 func (p *Pod) ReadyString() string {
 	return fmt.Sprintf("%d/%d", p.ready, p.total)
 }
@@ -160,30 +252,83 @@ func (p *Pod) containerNames() []string {
 	return names
 }
 
+func (p *Pod) hasContainer(name string) bool {
+	for index := range p.containers {
+		if p.containers[index].name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// containerKind distinguishes init and ephemeral (debug) containers from regular pod containers.
+type containerKind int
+
+const (
+	ContainerRegular containerKind = iota
+	ContainerInit
+	ContainerEphemeral
+)
+
 type Container struct {
-	name       string
-	image      string
-	version    string
+	name    string
+	kind    containerKind
+	image   string
+	version string
+	// state is a short container state, e.g. Running, Completed or CrashLoopBackOff.
+	state      string
 	message    string
+	restarts   int
 	ready      bool
+	resources  containerResources
+	usage      *containerUsage
+	details    []ContainerDetail
 	isExpanded bool
 	pod        *Pod
 }
 
-func (c Container) Type() Type {
+func (c *Container) Type() Type {
 	return TypeContainer
 }
 
-func (c Container) Expanded(b bool) {
+func (c *Container) Level() int {
+	return 3
+}
+
+func (c *Container) Expanded(b bool) {
 	c.isExpanded = b
 }
 
-func (c Container) IsExpanded() bool {
+func (c *Container) IsExpanded() bool {
 	return c.isExpanded
 }
 
-func (c Container) DisplayName() string {
-	return fmt.Sprintf("%v:%v", c.name, c.version)
+func (c *Container) DisplayName() string {
+	return fmt.Sprintf("%v%v:%v", c.marker(), c.name, c.version)
+}
+
+func (c *Container) marker() string {
+	switch c.kind {
+	case ContainerInit:
+		return "[init] "
+	case ContainerEphemeral:
+		return "[debug] "
+	default:
+		return ""
+	}
+}
+
+// StatusString returns container state followed by restart count and message when they are present, message is not
+// included when container is expanded as it is displayed in detail rows.
+func (c *Container) StatusString() string {
+	status := c.state
+	if c.restarts > 0 {
+		status += fmt.Sprintf(" (restarts %d)", c.restarts)
+	}
+	if c.message != "" && !c.isExpanded {
+		status += ": " + c.message
+	}
+	return status
 }
 
 type NamespaceError struct {
@@ -196,6 +341,10 @@ func (nse NamespaceError) Type() Type {
 	return TypeNamespaceError
 }
 
+func (nse NamespaceError) Level() int {
+	return 1
+}
+
 func (nse NamespaceError) Expanded(b bool) {
 	nse.isExpanded = b
 }
@@ -214,6 +363,10 @@ func (nsm NamespaceMessage) Type() Type {
 	return TypeNamespaceMessage
 }
 
+func (nsm NamespaceMessage) Level() int {
+	return 1
+}
+
 func (nsm NamespaceMessage) Expanded(b bool) {
 	nsm.isExpanded = b
 }
@@ -253,10 +406,14 @@ func (i *StringItem) UpdateS(s tcell.Screen, newValue string, style tcell.Style)
 	i.DrawS(s, style)
 }
 
-func toNamespace(plr *PodListResult) Namespace {
+func toNamespace(plr *PodListResult, grouping []groupingRule) Namespace {
 	ns := Namespace{
-		name:    plr.namespace,
-		context: plr.context,
+		name:               plr.namespace,
+		context:            plr.context,
+		reconnecting:       plr.reconnecting,
+		nextRetry:          plr.nextRetry,
+		metricsUnavailable: plr.metricsError != nil,
+		workloadsError:     plr.workloadsError,
 	}
 	ns.nsError = NamespaceError{
 		error:     plr.error,
@@ -270,48 +427,50 @@ func toNamespace(plr *PodListResult) Namespace {
 		}
 	}
 
-	ns.deployments = toPodGroup(plr.Items, &ns)
+	ns.deployments = toPodGroup(plr.Items, plr.owners, &ns, grouping)
+	for _, pg := range ns.deployments {
+		if status, ok := plr.workloads[pg.pods[0].controllerKey]; ok {
+			pg.workload = &status
+		}
+		for pIndex := range pg.pods {
+			podUsage := plr.metrics[pg.pods[pIndex].name]
+			for cIndex := range pg.pods[pIndex].containers {
+				if usage, ok := podUsage[pg.pods[pIndex].containers[cIndex].name]; ok {
+					pg.pods[pIndex].containers[cIndex].usage = &usage
+				}
+			}
+		}
+	}
+	attachEvents(&ns, toEvents(plr.events, &ns), plr.eventsError)
 	return ns
 }
 
-func toPodGroup(pods []v1.Pod, parent *Namespace) []*PodGroup {
+// toPodGroup groups pods using grouping rules, owners which were not resolved (e.g. in older recordings) are derived
+// from pod owner references, see fallbackOwner.
+func toPodGroup(pods []v1.Pod, owners map[string]podOwner, parent *Namespace, grouping []groupingRule) []*PodGroup {
 	podGroup := make(map[string]*PodGroup)
 
-	ownerName := ""
 	for _, pod := range pods {
-		for _, r := range pod.OwnerReferences {
-			if *r.Controller {
-				pos := strings.LastIndex(r.Name, "-")
-				ownerName = r.Name[:pos]
-				break // There can only be one controller, no point going further.
-			}
-		}
-
-		podGroupName := "_"
-		switch {
-		case ownerName != "":
-			podGroupName = ownerName
-		case pod.Labels["deployment"] != "":
-			podGroupName = pod.Labels["deployment"]
-		case pod.Labels["statefulSet"] != "":
-			podGroupName = pod.Labels["statefulSet"]
-		case pod.Labels["job-name"] != "":
-			podGroupName = pod.Labels["job-name"]
-		case pod.Labels["app"] != "":
-			podGroupName = pod.Labels["app"]
+		owner, ok := owners[pod.Name]
+		if !ok {
+			owner = fallbackOwner(&pod)
 		}
+		podGroupName := podGroupName(&pod, owner, grouping)
 
 		d, ok := podGroup[podGroupName]
 		if !ok {
 			d = &PodGroup{
 				name:       podGroupName,
+				ownerKind:  owner.Kind,
 				pods:       make([]Pod, 0),
 				isExpanded: false,
 				namespace:  parent,
 			}
 		}
 
-		d.pods = append(d.pods, toPod(pod, d))
+		p := toPod(pod, d)
+		p.owner = owner
+		d.pods = append(d.pods, p)
 		podGroup[podGroupName] = d
 	}
 
@@ -332,26 +491,64 @@ func toPodGroup(pods []v1.Pod, parent *Namespace) []*PodGroup {
 
 func toPod(p v1.Pod, parent *PodGroup) Pod {
 	pod := Pod{name: p.Name, podGroup: parent}
+	if ref := controllerRef(p.OwnerReferences); ref != nil {
+		pod.controllerKey = controllerKey(ref.Kind, ref.Name)
+	}
 
 	status, ready, total, restarts, creationTime := podStats(&p)
 
 	pod.status = status
+	pod.phase = p.Status.Phase
 	pod.ready = ready
 	pod.total = total
 	pod.restarts = restarts
 	pod.creationTime = creationTime
 	pod.age = translateTimestampSince(creationTime)
+	pod.details = toPodDetails(&p)
+	pod.lastRestart = lastRestartTime(&p)
 
+	resources := make(map[string]containerResources, len(p.Spec.InitContainers)+len(p.Spec.Containers))
+	for index := range p.Spec.InitContainers {
+		resources[p.Spec.InitContainers[index].Name] = toContainerResources(&p.Spec.InitContainers[index])
+	}
+	for index := range p.Spec.Containers {
+		resources[p.Spec.Containers[index].Name] = toContainerResources(&p.Spec.Containers[index])
+	}
+
+	// Containers are listed in the order they are started, init containers first and ephemeral containers last.
 	containers := make([]Container, 0)
+	for _, c := range p.Status.InitContainerStatuses {
+		containers = append(containers, toContainer(c, ContainerInit, resources[c.Name], &pod))
+	}
 	for _, c := range p.Status.ContainerStatuses {
-		containers = append(containers, toContainer(c, &pod))
+		containers = append(containers, toContainer(c, ContainerRegular, resources[c.Name], &pod))
+	}
+	for _, c := range p.Status.EphemeralContainerStatuses {
+		containers = append(containers, toContainer(c, ContainerEphemeral, containerResources{}, &pod))
+	}
+	for cIndex := range containers {
+		for dIndex := range containers[cIndex].details {
+			containers[cIndex].details[dIndex].container = &containers[cIndex]
+		}
 	}
 
 	pod.containers = containers
 	return pod
 }
 
-func toContainer(cs v1.ContainerStatus, parent *Pod) Container {
+// lastRestartTime returns the latest finish time of previous container runs, zero when no container was restarted.
+func lastRestartTime(pod *v1.Pod) time.Time {
+	var last time.Time
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.FinishedAt.After(last) {
+			last = cs.LastTerminationState.Terminated.FinishedAt.Time
+		}
+	}
+	return last
+}
+
+func toContainer(cs v1.ContainerStatus, kind containerKind, resources containerResources, parent *Pod) Container {
 	msg := ""
 	if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
 		msg = cs.State.Waiting.Message
@@ -365,13 +562,47 @@ func toContainer(cs v1.ContainerStatus, parent *Pod) Container {
 		version = cs.Image[versionPosition+1:]
 	}
 
+	// Finished init containers and running or finished ephemeral containers are not reported as ready, but they are
+	// not a problem either.
+	ready := cs.Ready
+	switch kind {
+	case ContainerInit:
+		ready = ready || (cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0)
+	case ContainerEphemeral:
+		ready = cs.State.Running != nil || (cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0)
+	}
+
 	return Container{
-		name:    cs.Name,
-		image:   cs.Image,
-		version: version,
-		message: msg,
-		ready:   cs.Ready,
-		pod:     parent,
+		name:      cs.Name,
+		kind:      kind,
+		image:     cs.Image,
+		version:   version,
+		state:     containerState(&cs),
+		message:   msg,
+		restarts:  int(cs.RestartCount),
+		ready:     ready,
+		resources: resources,
+		details:   toContainerDetails(&cs, resources),
+		pod:       parent,
+	}
+}
+
+func containerState(cs *v1.ContainerStatus) string {
+	switch {
+	case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+		return cs.State.Waiting.Reason
+	case cs.State.Waiting != nil:
+		return "Waiting"
+	case cs.State.Terminated != nil && cs.State.Terminated.Reason != "":
+		return cs.State.Terminated.Reason
+	case cs.State.Terminated != nil && cs.State.Terminated.Signal != 0:
+		return fmt.Sprintf("Signal:%d", cs.State.Terminated.Signal)
+	case cs.State.Terminated != nil:
+		return fmt.Sprintf("ExitCode:%d", cs.State.Terminated.ExitCode)
+	case cs.State.Running != nil:
+		return "Running"
+	default:
+		return ""
 	}
 }
 
@@ -387,13 +618,7 @@ func podStats(pod *v1.Pod) (status string, ready int, total int, restarts int, c
 		reason = pod.Status.Reason
 	}
 
-	// TODO: Check Job pod statuses.
-	//switch pod.Status.Phase {
-	//case v1.PodSucceeded:
-	//	row.Conditions = podSuccessConditions
-	//case api.PodFailed:
-	//	row.Conditions = podFailedConditions
-	//}
+	// Succeeded pods are reported as Completed by the logic below, they are treated as healthy, see Pod.isCompleted.
 
 	initializing := false
 	for i := range pod.Status.InitContainerStatuses {
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestToPodContainers(t *testing.T) {
+	p := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "migrate"}, {Name: "setup"}},
+			Containers:     []v1.Container{{Name: "app"}},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			InitContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "migrate",
+					Image: "migrate:1.0",
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}},
+				},
+				{
+					Name:         "setup",
+					Image:        "setup:2.0",
+					RestartCount: 3,
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+						Reason:  "CrashLoopBackOff",
+						Message: "back-off 40s restarting failed container",
+					}},
+				},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "app",
+					Image: "app:3.0",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "PodInitializing"}},
+				},
+			},
+			EphemeralContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "debugger",
+					Image: "busybox:latest",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+
+	pod := toPod(p, &PodGroup{name: "web"})
+
+	expected := []struct {
+		displayName string
+		status      string
+		ready       bool
+	}{
+		{"[init] migrate:1.0", "Completed", true},
+		{"[init] setup:2.0", "CrashLoopBackOff (restarts 3): back-off 40s restarting failed container", false},
+		{"app:3.0", "PodInitializing", false},
+		{"[debug] debugger:latest", "Running", true},
+	}
+
+	if len(pod.containers) != len(expected) {
+		t.Fatalf("Invalid number of containers. Want: %v, Got: %v", len(expected), len(pod.containers))
+	}
+	if pod.status != "Init:CrashLoopBackOff" {
+		t.Errorf("Invalid pod status. Want: %v, Got: %v", "Init:CrashLoopBackOff", pod.status)
+	}
+	for index, want := range expected {
+		c := pod.containers[index]
+		if c.DisplayName() != want.displayName {
+			t.Errorf("Invalid display name. Want: %v, Got: %v", want.displayName, c.DisplayName())
+		}
+		if c.StatusString() != want.status {
+			t.Errorf("Invalid status for %v. Want: %v, Got: %v", c.name, want.status, c.StatusString())
+		}
+		if c.ready != want.ready {
+			t.Errorf("Invalid ready for %v. Want: %v, Got: %v", c.name, want.ready, c.ready)
+		}
+	}
+}
+
+func TestPodGroupCountsSkipCompletedPods(t *testing.T) {
+	pg := PodGroup{name: "backup"}
+	pg.pods = []Pod{
+		{name: "backup-1", phase: v1.PodSucceeded, status: "Completed", ready: 0, total: 1, podGroup: &pg},
+		{name: "backup-2", phase: v1.PodRunning, status: "Running", ready: 1, total: 1, podGroup: &pg},
+		{name: "backup-3", phase: v1.PodFailed, status: "Error", ready: 0, total: 1, podGroup: &pg},
+	}
+
+	if ready := pg.countReadyPods(); ready != 1 {
+		t.Errorf("Invalid ready count. Want: %v, Got: %v", 1, ready)
+	}
+	if active := pg.countActivePods(); active != 2 {
+		t.Errorf("Invalid active count. Want: %v, Got: %v", 2, active)
+	}
+}
+
+func TestWorkloadsErrorOnPodGroups(t *testing.T) {
+	isController := true
+	plr := PodListResult{
+		namespace:      "ns",
+		context:        "context",
+		workloadsError: errors.New("deployments is forbidden"),
+		PodList: v1.PodList{Items: []v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-7d4b9c-x1", OwnerReferences: []metav1.OwnerReference{
+				{Kind: KindReplicaSet, Name: "web-7d4b9c", Controller: &isController},
+			}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "standalone"}},
+		}},
+	}
+	ns := toNamespace(&plr, defaultGroupingRules())
+
+	infos := make(map[string]string)
+	for _, pg := range ns.deployments {
+		infos[pg.pods[0].name] = pg.workloadUnavailable()
+	}
+	if expected := "replicas n/a: deployments is forbidden"; infos["web-7d4b9c-x1"] != expected {
+		t.Errorf("Invalid workload info. Want: %v, Got: %v", expected, infos["web-7d4b9c-x1"])
+	}
+	if infos["standalone"] != "" {
+		t.Errorf("Group without controller should not show workload info, got '%v'", infos["standalone"])
+	}
+}
@@ -0,0 +1,221 @@
+package app
+
+import (
+	"fmt"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+const (
+	KindReplicaSet  = "ReplicaSet"
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// workloadStatus holds replica counts of the controller owning pods in a PodGroup, Jobs and CronJobs use completion
+// counts and schedule times instead. Fields are exported so it can be recorded together with pod lists.
+type workloadStatus struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Desired   int32  `json:"desired"`
+	Updated   int32  `json:"updated"`
+	Available int32  `json:"available"`
+	// Job and CronJob only.
+	Active         int32     `json:"active,omitempty"`
+	Succeeded      int32     `json:"succeeded,omitempty"`
+	Failed         int32     `json:"failed,omitempty"`
+	LastSchedule   time.Time `json:"lastSchedule,omitempty"`
+	LastSuccessful time.Time `json:"lastSuccessful,omitempty"`
+	LastJobFailed  bool      `json:"lastJobFailed,omitempty"`
+}
+
+// isDegraded returns true when available replicas are below desired, Job is degraded when it has failed pods and is
+// not complete, CronJob when its most recently finished Job failed.
+func (ws workloadStatus) isDegraded() bool {
+	switch ws.Kind {
+	case KindJob:
+		return ws.Failed > 0 && ws.Succeeded < ws.Desired
+	case KindCronJob:
+		return ws.LastJobFailed
+	default:
+		return ws.Available < ws.Desired
+	}
+}
+
+func (ws workloadStatus) DisplayName() string {
+	switch ws.Kind {
+	case KindJob:
+		return fmt.Sprintf("completions %d/%d  active %d  failed %d", ws.Succeeded, ws.Desired, ws.Active, ws.Failed)
+	case KindCronJob:
+		return fmt.Sprintf("last schedule %v  last successful %v  active %d", timeAgo(ws.LastSchedule), timeAgo(ws.LastSuccessful), ws.Active)
+	default:
+		return fmt.Sprintf("desired %d  updated %d  available %d", ws.Desired, ws.Updated, ws.Available)
+	}
+}
+
+func timeAgo(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return translateTimestampSince(t) + " ago"
+}
+
+// namespaceDetails is everything fetched for a namespace on refresh interval in addition to watched pods.
+type namespaceDetails struct {
+	events      []v1.Event
+	eventsError error
+	// workloads are keyed by pod controller reference, see controllerKey.
+	workloads      map[string]workloadStatus
+	workloadsError error
+	metrics        map[string]map[string]containerUsage
+	metricsError   error
+}
+
+// workloadUnavailable describes why controller status of the group is not displayed, empty when it is displayed or
+// the group has no controller.
+func (pg *PodGroup) workloadUnavailable() string {
+	if pg.workload != nil || pg.namespace == nil || pg.namespace.workloadsError == nil {
+		return ""
+	}
+	if len(pg.pods) == 0 || pg.pods[0].controllerKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("replicas n/a: %v", pg.namespace.workloadsError)
+}
+
+func controllerKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// controllerRef returns controller owner reference, or nil if there is none.
+func controllerRef(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	for index := range refs {
+		if refs[index].Controller != nil && *refs[index].Controller {
+			return &refs[index]
+		}
+	}
+	return nil
+}
+
+// controllerKinds returns kinds of controllers owning pods, so only required workload types are listed.
+func controllerKinds(pods []v1.Pod) map[string]struct{} {
+	kinds := make(map[string]struct{})
+	for index := range pods {
+		if ref := controllerRef(pods[index].OwnerReferences); ref != nil {
+			kinds[ref.Kind] = struct{}{}
+		}
+	}
+	return kinds
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		// Defaults to 1 when not specified.
+		return 1
+	}
+	return *r
+}
+
+func deploymentStatus(d *appsv1.Deployment) workloadStatus {
+	return workloadStatus{
+		Kind:      KindDeployment,
+		Name:      d.Name,
+		Desired:   replicas(d.Spec.Replicas),
+		Updated:   d.Status.UpdatedReplicas,
+		Available: d.Status.AvailableReplicas,
+	}
+}
+
+func replicaSetStatus(rs *appsv1.ReplicaSet) workloadStatus {
+	return workloadStatus{
+		Kind:      KindReplicaSet,
+		Name:      rs.Name,
+		Desired:   replicas(rs.Spec.Replicas),
+		Updated:   rs.Status.Replicas,
+		Available: rs.Status.AvailableReplicas,
+	}
+}
+
+func statefulSetStatus(ss *appsv1.StatefulSet) workloadStatus {
+	return workloadStatus{
+		Kind:      KindStatefulSet,
+		Name:      ss.Name,
+		Desired:   replicas(ss.Spec.Replicas),
+		Updated:   ss.Status.UpdatedReplicas,
+		Available: ss.Status.ReadyReplicas,
+	}
+}
+
+func daemonSetStatus(ds *appsv1.DaemonSet) workloadStatus {
+	return workloadStatus{
+		Kind:      KindDaemonSet,
+		Name:      ds.Name,
+		Desired:   ds.Status.DesiredNumberScheduled,
+		Updated:   ds.Status.UpdatedNumberScheduled,
+		Available: ds.Status.NumberAvailable,
+	}
+}
+
+func jobStatus(job *batchv1.Job) workloadStatus {
+	return workloadStatus{
+		Kind:      KindJob,
+		Name:      job.Name,
+		Desired:   replicas(job.Spec.Completions),
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+	}
+}
+
+// cronJobStatus uses Jobs owned by the CronJob to find last successful time and whether the most recently finished Job
+// failed, as batch/v1beta1 CronJob status has only the last schedule time.
+func cronJobStatus(cj *batchv1beta1.CronJob, jobs []*batchv1.Job) workloadStatus {
+	status := workloadStatus{
+		Kind:   KindCronJob,
+		Name:   cj.Name,
+		Active: int32(len(cj.Status.Active)),
+	}
+	if cj.Status.LastScheduleTime != nil {
+		status.LastSchedule = cj.Status.LastScheduleTime.Time
+	}
+
+	var lastFinished time.Time
+	for _, job := range jobs {
+		finished, failed := jobFinished(job)
+		if finished.IsZero() {
+			continue
+		}
+		if !failed && finished.After(status.LastSuccessful) {
+			status.LastSuccessful = finished
+		}
+		if finished.After(lastFinished) {
+			lastFinished = finished
+			status.LastJobFailed = failed
+		}
+	}
+	return status
+}
+
+// jobFinished returns time when Job completed or failed, zero time is returned for Jobs which are still running.
+func jobFinished(job *batchv1.Job) (finished time.Time, failed bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != v1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			if job.Status.CompletionTime != nil {
+				return job.Status.CompletionTime.Time, false
+			}
+			return c.LastTransitionTime.Time, false
+		case batchv1.JobFailed:
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -1,25 +1,111 @@
 package clipboard
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"strings"
 )
 
-func ToClipboard(s string) error {
-	copyCmd := exec.Command("pbcopy")
-	in, err := copyCmd.StdinPipe()
-	if err != nil {
-		return err
+const (
+	Auto   = "auto"
+	Pbcopy = "pbcopy"
+	WlCopy = "wl-copy"
+	Xclip  = "xclip"
+	Xsel   = "xsel"
+	OSC52  = "osc52"
+)
+
+// Backend copies a value to the system clipboard.
+type Backend interface {
+	Name() string
+	Copy(value string) error
+}
+
+// Clipboard tries its backends in order until one of them succeeds.
+type Clipboard struct {
+	backends []Backend
+	osc52    *osc52
+}
+
+// New returns clipboard using a single backend by its name or, for Auto, all backends in order suitable for current
+// session: local clipboard tools only, OSC 52 is added first when running over SSH and last when running inside tmux.
+// OSC 52 can not report failures, so it is not used otherwise and an error listing tried backends is returned instead.
+func New(name string) (*Clipboard, error) {
+	terminal := &osc52{}
+	backends := map[string]Backend{
+		Pbcopy: commandBackend{name: Pbcopy},
+		WlCopy: commandBackend{name: WlCopy, requiredEnv: "WAYLAND_DISPLAY"},
+		Xclip:  commandBackend{name: Xclip, args: []string{"-selection", "clipboard"}, requiredEnv: "DISPLAY"},
+		Xsel:   commandBackend{name: Xsel, args: []string{"--clipboard", "--input"}, requiredEnv: "DISPLAY"},
+		OSC52:  terminal,
 	}
-	if err := copyCmd.Start(); err != nil {
-		return err
+
+	if name != Auto {
+		backend, ok := backends[name]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("invalid clipboard '%v', should be one of %v, %v, %v, %v, %v or %v", name, Auto, Pbcopy, WlCopy, Xclip, Xsel, OSC52))
+		}
+		return &Clipboard{backends: []Backend{backend}, osc52: terminal}, nil
 	}
-	if _, err := in.Write([]byte(s)); err != nil {
-		return err
+
+	local := []Backend{backends[Pbcopy], backends[WlCopy], backends[Xclip], backends[Xsel]}
+	switch {
+	case isRemoteSession():
+		return &Clipboard{backends: append([]Backend{terminal}, local...), osc52: terminal}, nil
+	case isTmux():
+		return &Clipboard{backends: append(local, terminal), osc52: terminal}, nil
+	default:
+		return &Clipboard{backends: local, osc52: terminal}, nil
 	}
-	if err := in.Close(); err != nil {
-		return err
+}
+
+// SetTerminal sets function writing escape sequences to the terminal, it is used by OSC 52 backend. The application
+// drawing the screen has to provide it, so sequences are not written in the middle of a screen update.
+func (c *Clipboard) SetTerminal(write func(sequence string) error) {
+	c.osc52.write = write
+}
+
+func isRemoteSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// Copy returns error listing every backend tried with its failure when none of them succeeded.
+func (c *Clipboard) Copy(value string) error {
+	failures := make([]string, 0, len(c.backends))
+	for _, backend := range c.backends {
+		err := backend.Copy(value)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, fmt.Sprintf("%v: %v", backend.Name(), err))
 	}
+	return errors.New(fmt.Sprintf("copy to clipboard failed, tried %v", strings.Join(failures, "; ")))
+}
+
+// commandBackend pipes the value to a clipboard tool, requiredEnv has to be set for the tool to work, e.g. DISPLAY.
+type commandBackend struct {
+	name        string
+	args        []string
+	requiredEnv string
+}
 
-	return copyCmd.Wait()
+func (b commandBackend) Name() string {
+	return b.name
+}
+
+func (b commandBackend) Copy(value string) error {
+	if b.requiredEnv != "" && os.Getenv(b.requiredEnv) == "" {
+		return errors.New(b.requiredEnv + " is not set")
+	}
+	path, err := exec.LookPath(b.name)
+	if err != nil {
+		return errors.New("not found")
+	}
 
+	// Output is not captured, as xclip keeps running in background to serve the selection and holds it open.
+	copyCmd := exec.Command(path, b.args...)
+	copyCmd.Stdin = strings.NewReader(value)
+	return copyCmd.Run()
 }
@@ -0,0 +1,112 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+type fakeBackend struct {
+	name   string
+	err    error
+	copied string
+}
+
+func (b *fakeBackend) Name() string {
+	return b.name
+}
+
+func (b *fakeBackend) Copy(value string) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.copied = value
+	return nil
+}
+
+func TestClipboardCopy(t *testing.T) {
+	first := &fakeBackend{name: "first", err: errors.New("not found")}
+	second := &fakeBackend{name: "second"}
+	cb := Clipboard{backends: []Backend{first, second}}
+
+	if err := cb.Copy("kubectl get pods"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if second.copied != "kubectl get pods" {
+		t.Errorf("Value should be copied by the second backend, got: %q", second.copied)
+	}
+
+	second.err = errors.New("DISPLAY is not set")
+	err := cb.Copy("kubectl get pods")
+	expected := "copy to clipboard failed, tried first: not found; second: DISPLAY is not set"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Invalid error. Want: %v, Got: %v", expected, err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"SSH_TTY", "SSH_CONNECTION", "TMUX"} {
+		value, ok := os.LookupEnv(name)
+		defer func(name string) {
+			if ok {
+				_ = os.Setenv(name, value)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		}(name)
+	}
+
+	if _, err := New("clip.exe"); err == nil {
+		t.Errorf("Expected error for unknown clipboard")
+	}
+
+	cb, err := New(Xsel)
+	if err != nil || len(cb.backends) != 1 || cb.backends[0].Name() != Xsel {
+		t.Errorf("Expected single xsel backend, got: %v, %v", cb, err)
+	}
+
+	_ = os.Unsetenv("SSH_TTY")
+	_ = os.Unsetenv("SSH_CONNECTION")
+	_ = os.Unsetenv("TMUX")
+	cb, _ = New(Auto)
+	for _, backend := range cb.backends {
+		if backend.Name() == OSC52 {
+			t.Errorf("OSC 52 should not be used in a local session outside tmux")
+		}
+	}
+
+	_ = os.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	cb, _ = New(Auto)
+	if last := cb.backends[len(cb.backends)-1]; last.Name() != OSC52 {
+		t.Errorf("OSC 52 should be tried last inside tmux, got: %v", last.Name())
+	}
+
+	_ = os.Setenv("SSH_TTY", "/dev/pts/1")
+	cb, _ = New(Auto)
+	if cb.backends[0].Name() != OSC52 {
+		t.Errorf("OSC 52 should be tried first over SSH, got: %v", cb.backends[0].Name())
+	}
+}
+
+func TestOSC52(t *testing.T) {
+	if seq := osc52Sequence("hello", false); seq != "\x1b]52;c;aGVsbG8=\x07" {
+		t.Errorf("Invalid sequence: %q", seq)
+	}
+	if seq := osc52Sequence("hello", true); seq != "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x07\x1b\\" {
+		t.Errorf("Invalid tmux sequence: %q", seq)
+	}
+
+	cb, _ := New(OSC52)
+	if err := cb.Copy("hello"); err == nil {
+		t.Errorf("Copy should fail before terminal is set")
+	}
+	written := ""
+	cb.SetTerminal(func(sequence string) error {
+		written += sequence
+		return nil
+	})
+	if err := cb.Copy("hello"); err != nil || !strings.Contains(written, "aGVsbG8=") {
+		t.Errorf("Sequence should be written to terminal, got: %q, %v", written, err)
+	}
+}
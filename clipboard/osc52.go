@@ -0,0 +1,40 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+)
+
+// osc52 asks the terminal emulator to set its clipboard with OSC 52 escape sequence, which also works over SSH.
+// Terminal has to support it and, for tmux, 'set-clipboard' has to be enabled. Sequence is written by the application
+// owning the terminal, see Clipboard.SetTerminal, so it does not interleave with screen updates. There is no reply from
+// the terminal, so the copy is best effort.
+type osc52 struct {
+	write func(sequence string) error
+}
+
+func (o *osc52) Name() string {
+	return OSC52
+}
+
+func (o *osc52) Copy(value string) error {
+	if o.write == nil {
+		return errors.New("terminal is not available")
+	}
+	return o.write(osc52Sequence(value, isTmux()))
+}
+
+func isTmux() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// osc52Sequence returns sequence setting clipboard to value, inside tmux it is wrapped in passthrough sequence.
+func osc52Sequence(value string, tmux bool) string {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(value)) + "\x07"
+	if tmux {
+		return "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return sequence
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/JLevconoks/k8ConsoleViewer/app"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "replay a file recorded with --record",
+	Args:  cobra.ExactArgs(1),
+	Run:   runReplayCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+}
+
+func runReplayCmd(cmd *cobra.Command, args []string) {
+	settings := viper.AllSettings()
+	k8App, err := app.NewReplayApp(args[0], settings)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	k8App.Run()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/viper"
 	"log"
 	"os"
+	"time"
 )
 
 var rootCmd = &cobra.Command{
@@ -21,6 +22,10 @@ var (
 	buildTime    = ""
 	namespace    string
 	context      string
+	interval     time.Duration
+	kubeconfig   string
+	recordPath   string
+	selector     string
 )
 
 func Execute() {
@@ -32,9 +37,17 @@ func Execute() {
 
 func init() {
 	rootCmd.Flags()
-	rootCmd.Flags().StringVarP(&context, "context", "c", "", "context value, defaults to current context in .kube config")
+	rootCmd.Flags().StringVarP(&context, "context", "c", "", "context value, defaults to current context in kubeconfig")
 	rootCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace value")
 	rootCmd.MarkFlagRequired("namespace")
+	rootCmd.PersistentFlags().DurationVar(&interval, "interval", 5*time.Second, "refresh interval, also used as a starting delay when retrying failed namespaces")
+	_ = viper.BindPFlag("interval", rootCmd.PersistentFlags().Lookup("interval"))
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file, defaults to KUBECONFIG files or ~/.kube/config")
+	_ = viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
+	rootCmd.PersistentFlags().StringVar(&recordPath, "record", "", "append every refresh to a file, which can be viewed later with replay command")
+	_ = viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	rootCmd.PersistentFlags().StringVarP(&selector, "selector", "l", "", "label selector for pods, combined with labelSelector from groups.json")
+	_ = viper.BindPFlag("selector", rootCmd.PersistentFlags().Lookup("selector"))
 
 	rootCmd.Version = fmt.Sprintf("%s (%s)", buildVersion, buildTime)
 }
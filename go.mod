@@ -20,4 +20,6 @@ require (
 	k8s.io/api v0.20.1
 	k8s.io/apimachinery v0.20.1
 	k8s.io/client-go v0.20.1
+	k8s.io/metrics v0.20.1
+	sigs.k8s.io/yaml v1.2.0
 )
@@ -51,7 +51,11 @@ async def main(connection):
 
 iterm2.run_until_complete(main)`
 
-func OpenAndExecute(commands []string) error {
+// ITerm opens a new iTerm2 window through its Python API, splits it into a session per command and broadcasts input
+// to all of them. Requires python3 with iterm2 and pyobjc packages.
+type ITerm struct{}
+
+func (ITerm) OpenAndExecute(commands []string) error {
 	fm := template.FuncMap{
 		"remainder": func(i, j int) int { return i % j },
 		"div":       func(i, j int) int { return i / j },
@@ -0,0 +1,28 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	BackendITerm = "iterm2"
+	BackendTmux  = "tmux"
+)
+
+// Backend opens a new terminal window with a pane for every command and input broadcast to all panes.
+type Backend interface {
+	OpenAndExecute(commands []string) error
+}
+
+// NewBackend returns backend by its name from config.yaml.
+func NewBackend(name string) (Backend, error) {
+	switch name {
+	case BackendITerm:
+		return ITerm{}, nil
+	case BackendTmux:
+		return NewTmux(), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid terminal '%v', should be '%v' or '%v'", name, BackendITerm, BackendTmux))
+	}
+}
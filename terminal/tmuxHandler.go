@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const tmuxWindowName = "k8ConsoleViewer"
+
+// Tmux opens a new window in the current tmux session, tiles a pane per command and turns on synchronize-panes, so
+// input is sent to all panes. App has to be running inside tmux.
+type Tmux struct {
+	// run executes tmux with given arguments and returns its output, replaced in tests.
+	run func(args ...string) (string, error)
+}
+
+func NewTmux() Tmux {
+	return Tmux{run: runTmux}
+}
+
+func runTmux(args ...string) (string, error) {
+	out, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("tmux %v failed: %v %v", args[0], strings.TrimSpace(string(out)), err))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (t Tmux) OpenAndExecute(commands []string) error {
+	if len(commands) == 0 {
+		return nil
+	}
+	if os.Getenv("TMUX") == "" {
+		return errors.New("tmux terminal requires k8ConsoleViewer to be running inside a tmux session")
+	}
+
+	// Commands are typed into a shell rather than run directly, so panes stay open after commands finish.
+	windowID, err := t.run("new-window", "-P", "-F", "#{window_id}", "-n", tmuxWindowName)
+	if err != nil {
+		return err
+	}
+	if err := t.sendCommand(windowID, commands[0]); err != nil {
+		return err
+	}
+
+	for _, command := range commands[1:] {
+		paneID, err := t.run("split-window", "-t", windowID, "-P", "-F", "#{pane_id}")
+		if err != nil {
+			return err
+		}
+		// Layout is applied after every split, otherwise tmux runs out of space for new panes.
+		if _, err := t.run("select-layout", "-t", windowID, "tiled"); err != nil {
+			return err
+		}
+		if err := t.sendCommand(paneID, command); err != nil {
+			return err
+		}
+	}
+
+	// Synchronizing is turned on last, so commands above are not sent to every pane.
+	_, err = t.run("set-window-option", "-t", windowID, "synchronize-panes", "on")
+	return err
+}
+
+func (t Tmux) sendCommand(target, command string) error {
+	if _, err := t.run("send-keys", "-t", target, "-l", command); err != nil {
+		return err
+	}
+	_, err := t.run("send-keys", "-t", target, "Enter")
+	return err
+}
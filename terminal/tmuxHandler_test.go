@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTmuxOpenAndExecute(t *testing.T) {
+	tmuxEnv, ok := os.LookupEnv("TMUX")
+	defer func() {
+		if ok {
+			_ = os.Setenv("TMUX", tmuxEnv)
+		} else {
+			_ = os.Unsetenv("TMUX")
+		}
+	}()
+
+	calls := make([]string, 0)
+	tmux := Tmux{run: func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		switch args[0] {
+		case "new-window":
+			return "@1", nil
+		case "split-window":
+			return "%2", nil
+		}
+		return "", nil
+	}}
+
+	_ = os.Unsetenv("TMUX")
+	if err := tmux.OpenAndExecute([]string{"kubectl logs web-1"}); err == nil {
+		t.Errorf("Expected error when not running inside tmux")
+	}
+
+	_ = os.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if err := tmux.OpenAndExecute([]string{"kubectl logs web-1", "kubectl logs web-2"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"new-window -P -F #{window_id} -n k8ConsoleViewer",
+		"send-keys -t @1 -l kubectl logs web-1",
+		"send-keys -t @1 Enter",
+		"split-window -t @1 -P -F #{pane_id}",
+		"select-layout -t @1 tiled",
+		"send-keys -t %2 -l kubectl logs web-2",
+		"send-keys -t %2 Enter",
+		"set-window-option -t @1 synchronize-panes on",
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Invalid tmux calls.\nWant: %v\nGot:  %v", expected, calls)
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	if _, err := NewBackend("konsole"); err == nil {
+		t.Errorf("Expected error for unknown terminal")
+	}
+	if backend, err := NewBackend(BackendTmux); err != nil || backend == nil {
+		t.Errorf("Unexpected result for tmux: %v, %v", backend, err)
+	}
+}